@@ -0,0 +1,404 @@
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	httpclient "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagMaxBlockAge  = "max-block-age"
+	flagMaxHeightLag = "max-height-lag"
+	flagTimeout      = "timeout"
+)
+
+const (
+	nodeCheckStatusOk   = "OK"
+	nodeCheckStatusWarn = "WARN"
+	nodeCheckStatusFail = "FAIL"
+)
+
+type nodeCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+type nodeTmInfo struct {
+	Endpoint        string `json:"endpoint"`
+	NodeId          string `json:"nodeId"`
+	Moniker         string `json:"moniker"`
+	Network         string `json:"network"`
+	Version         string `json:"version"`
+	LatestHeight    int64  `json:"latestHeight"`
+	LatestBlockTime string `json:"latestBlockTime"`
+	CatchingUp      bool   `json:"catchingUp"`
+}
+
+type nodeEvmInfo struct {
+	Endpoint    string `json:"endpoint"`
+	BlockNumber uint64 `json:"blockNumber"`
+	ChainId     string `json:"chainId"`
+	PeerCount   *int64 `json:"peerCount,omitempty"`
+}
+
+type nodeRestInfo struct {
+	Endpoint         string `json:"endpoint"`
+	NodeId           string `json:"nodeId"`
+	Moniker          string `json:"moniker"`
+	Network          string `json:"network"`
+	Version          string `json:"version"`
+	AppName          string `json:"appName"`
+	AppVersion       string `json:"appVersion"`
+	CosmosSdkVersion string `json:"cosmosSdkVersion"`
+}
+
+type nodeCheckOutput struct {
+	Healthy bool          `json:"healthy"`
+	Tm      *nodeTmInfo   `json:"tm,omitempty"`
+	Evm     *nodeEvmInfo  `json:"evm,omitempty"`
+	Rest    *nodeRestInfo `json:"rest,omitempty"`
+	Checks  []nodeCheck   `json:"checks"`
+}
+
+func (o *nodeCheckOutput) add(name, status, detail string) {
+	o.Checks = append(o.Checks, nodeCheck{
+		Name:   name,
+		Status: status,
+		Detail: detail,
+	})
+	if status == nodeCheckStatusFail {
+		o.Healthy = false
+	}
+}
+
+func GetCheckNodeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Check health and consistency of the Tendermint RPC, EVM Json-RPC and Rest API endpoints",
+		Long: `Check health and consistency of the Tendermint RPC, EVM Json-RPC and Rest API endpoints.
+Mismatches are reported: EVM height lagging Tendermint height, EVM chain ID mismatch the Cosmos chain-id, stale block time, etc.
+Exit with non-zero code if any check failed, can be used as readiness probe.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			maxBlockAge, err := cmd.Flags().GetDuration(flagMaxBlockAge)
+			utils.ExitOnErr(err, "failed to read max block age")
+
+			maxHeightLag, err := cmd.Flags().GetInt64(flagMaxHeightLag)
+			utils.ExitOnErr(err, "failed to read max height lag")
+
+			timeout, err := cmd.Flags().GetDuration(flagTimeout)
+			utils.ExitOnErr(err, "failed to read timeout")
+
+			tmRpc, _ := flags.ReadTmRpc(cmd)
+			evmRpc, _ := flags.ReadEvmRpc(cmd)
+			rest, _ := flags.ReadCosmosRest(cmd)
+
+			output := checkNode(tmRpc, evmRpc, rest, maxBlockAge, maxHeightLag, timeout)
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				if output.Tm != nil {
+					fmt.Println("Tendermint RPC:", output.Tm.Endpoint)
+					fmt.Println("  Node ID     :", output.Tm.NodeId)
+					fmt.Println("  Moniker     :", output.Tm.Moniker)
+					fmt.Println("  Network     :", output.Tm.Network)
+					fmt.Println("  Version     :", output.Tm.Version)
+					fmt.Println("  Height      :", output.Tm.LatestHeight)
+					fmt.Println("  Block time  :", output.Tm.LatestBlockTime)
+					fmt.Println("  Catching up :", output.Tm.CatchingUp)
+				}
+				if output.Evm != nil {
+					fmt.Println("EVM Json-RPC:", output.Evm.Endpoint)
+					fmt.Println("  Block number:", output.Evm.BlockNumber)
+					fmt.Println("  Chain ID    :", output.Evm.ChainId)
+					if output.Evm.PeerCount != nil {
+						fmt.Println("  Peer count  :", *output.Evm.PeerCount)
+					}
+				}
+				if output.Rest != nil {
+					fmt.Println("Rest API:", output.Rest.Endpoint)
+					fmt.Println("  Node ID     :", output.Rest.NodeId)
+					fmt.Println("  Network     :", output.Rest.Network)
+					fmt.Println("  App         :", output.Rest.AppName, output.Rest.AppVersion)
+					fmt.Println("  Cosmos-SDK  :", output.Rest.CosmosSdkVersion)
+				}
+
+				fmt.Println()
+				for _, check := range output.Checks {
+					fmt.Printf("%-4s | %-22s | %s\n", check.Status, check.Name, check.Detail)
+				}
+
+				fmt.Println()
+				if output.Healthy {
+					fmt.Println("Healthy")
+				} else {
+					fmt.Println("Unhealthy")
+				}
+			})
+
+			if !output.Healthy {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagTendermintRpc, "", flags.FlagTmRpcDesc)
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+	cmd.Flags().Duration(flagMaxBlockAge, time.Minute, "latest block older than this is considered stale")
+	cmd.Flags().Int64(flagMaxHeightLag, 5, "maximum allowed difference between EVM block number and Tendermint height")
+	cmd.Flags().Duration(flagTimeout, 10*time.Second, "timeout of each request")
+
+	return cmd
+}
+
+func checkNode(tmRpc, evmRpc, rest string, maxBlockAge time.Duration, maxHeightLag int64, timeout time.Duration) nodeCheckOutput {
+	output := nodeCheckOutput{
+		Healthy: true,
+	}
+
+	// Tendermint RPC
+	tmInfo, err := fetchNodeTmInfo(tmRpc, timeout)
+	if err != nil {
+		output.add("tm-rpc", nodeCheckStatusFail, err.Error())
+	} else {
+		output.Tm = tmInfo
+		output.add("tm-rpc", nodeCheckStatusOk, fmt.Sprintf("height %d", tmInfo.LatestHeight))
+
+		if tmInfo.CatchingUp {
+			output.add("tm-catching-up", nodeCheckStatusFail, "node is catching up")
+		} else {
+			output.add("tm-catching-up", nodeCheckStatusOk, "node is synced")
+		}
+
+		if latestBlockTime, err := time.Parse(time.RFC3339Nano, tmInfo.LatestBlockTime); err == nil {
+			age := time.Since(latestBlockTime).Round(time.Second)
+			if age > maxBlockAge {
+				output.add("tm-block-time", nodeCheckStatusFail, fmt.Sprintf("latest block is stale, produced %s ago (max %s)", age, maxBlockAge))
+			} else {
+				output.add("tm-block-time", nodeCheckStatusOk, fmt.Sprintf("latest block produced %s ago", age))
+			}
+		}
+	}
+
+	// EVM Json-RPC
+	evmInfo, peerCountErr, err := fetchNodeEvmInfo(evmRpc, timeout)
+	if err != nil {
+		output.add("evm-rpc", nodeCheckStatusFail, err.Error())
+	} else {
+		output.Evm = evmInfo
+		output.add("evm-rpc", nodeCheckStatusOk, fmt.Sprintf("block number %d, chain ID %s", evmInfo.BlockNumber, evmInfo.ChainId))
+
+		if peerCountErr != nil {
+			output.add("evm-peer-count", nodeCheckStatusWarn, peerCountErr.Error())
+		} else if *evmInfo.PeerCount < 1 {
+			output.add("evm-peer-count", nodeCheckStatusWarn, "no peer")
+		} else {
+			output.add("evm-peer-count", nodeCheckStatusOk, fmt.Sprintf("%d peers", *evmInfo.PeerCount))
+		}
+	}
+
+	// Rest API
+	restInfo, err := fetchNodeRestInfo(rest, timeout)
+	if err != nil {
+		output.add("rest", nodeCheckStatusFail, err.Error())
+	} else {
+		output.Rest = restInfo
+		output.add("rest", nodeCheckStatusOk, fmt.Sprintf("%s %s", restInfo.AppName, restInfo.AppVersion))
+	}
+
+	// consistency
+	if output.Tm != nil && output.Evm != nil {
+		lag := output.Tm.LatestHeight - int64(output.Evm.BlockNumber)
+		if lag < 0 {
+			lag = -lag
+		}
+		detail := fmt.Sprintf("EVM block number %d, Tendermint height %d", output.Evm.BlockNumber, output.Tm.LatestHeight)
+		if lag > maxHeightLag {
+			output.add("evm-height-lag", nodeCheckStatusFail, fmt.Sprintf("%s, lag %d blocks (max %d)", detail, lag, maxHeightLag))
+		} else {
+			output.add("evm-height-lag", nodeCheckStatusOk, detail)
+		}
+	}
+
+	var network string
+	if output.Tm != nil {
+		network = output.Tm.Network
+	} else if output.Rest != nil {
+		network = output.Rest.Network
+	}
+
+	if output.Tm != nil && output.Rest != nil {
+		if output.Tm.Network != output.Rest.Network {
+			output.add("network", nodeCheckStatusFail, fmt.Sprintf("Tendermint RPC network %s mismatch Rest API network %s", output.Tm.Network, output.Rest.Network))
+		} else if output.Tm.NodeId != output.Rest.NodeId {
+			output.add("network", nodeCheckStatusWarn, fmt.Sprintf("Tendermint RPC node %s differs from Rest API node %s", output.Tm.NodeId, output.Rest.NodeId))
+		} else {
+			output.add("network", nodeCheckStatusOk, network)
+		}
+	}
+
+	if output.Evm != nil && network != "" {
+		if evmChainId, ok := utils.ParseEvmChainIdFromCosmosChainId(network); !ok {
+			output.add("evm-chain-id", nodeCheckStatusWarn, fmt.Sprintf("chain-id %s is not in Ethermint format, can not compare with EVM chain ID %s", network, output.Evm.ChainId))
+		} else if evmChainId.String() != output.Evm.ChainId {
+			output.add("evm-chain-id", nodeCheckStatusFail, fmt.Sprintf("EVM chain ID %s mismatch chain-id %s", output.Evm.ChainId, network))
+		} else {
+			output.add("evm-chain-id", nodeCheckStatusOk, fmt.Sprintf("EVM chain ID %s matches chain-id %s", output.Evm.ChainId, network))
+		}
+	}
+
+	return output
+}
+
+func fetchNodeTmInfo(tmRpc string, timeout time.Duration) (*nodeTmInfo, error) {
+	tendermintRpcHttpClient, err := httpclient.New(tmRpc, "/websocket")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Tendermint RPC client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resStatus, err := tendermintRpcHttpClient.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get status")
+	}
+
+	return &nodeTmInfo{
+		Endpoint:        tmRpc,
+		NodeId:          string(resStatus.NodeInfo.DefaultNodeID),
+		Moniker:         resStatus.NodeInfo.Moniker,
+		Network:         resStatus.NodeInfo.Network,
+		Version:         resStatus.NodeInfo.Version,
+		LatestHeight:    resStatus.SyncInfo.LatestBlockHeight,
+		LatestBlockTime: resStatus.SyncInfo.LatestBlockTime.Format(time.RFC3339Nano),
+		CatchingUp:      resStatus.SyncInfo.CatchingUp,
+	}, nil
+}
+
+// fetchNodeEvmInfo queries block number, chain ID and peer count,
+// failure of peer count is returned separately since 'net' namespace can be disabled.
+func fetchNodeEvmInfo(evmRpc string, timeout time.Duration) (evmInfo *nodeEvmInfo, peerCountErr, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	rpcClient, err := rpc.DialContext(ctx, evmRpc)
+	if err != nil {
+		err = errors.Wrap(err, "failed to connect")
+		return
+	}
+	defer rpcClient.Close()
+
+	var blockNumber hexutil.Uint64
+	if err = rpcClient.CallContext(ctx, &blockNumber, "eth_blockNumber"); err != nil {
+		err = errors.Wrap(err, "failed to get block number")
+		return
+	}
+
+	var chainId hexutil.Big
+	if err = rpcClient.CallContext(ctx, &chainId, "eth_chainId"); err != nil {
+		err = errors.Wrap(err, "failed to get chain ID")
+		return
+	}
+
+	evmInfo = &nodeEvmInfo{
+		Endpoint:    evmRpc,
+		BlockNumber: uint64(blockNumber),
+		ChainId:     (*big.Int)(&chainId).String(),
+	}
+
+	var peerCount json.RawMessage
+	if peerCountErr = rpcClient.CallContext(ctx, &peerCount, "net_peerCount"); peerCountErr != nil {
+		peerCountErr = errors.Wrap(peerCountErr, "failed to get peer count")
+	} else if count, err := parsePeerCount(peerCount); err != nil {
+		peerCountErr = errors.Wrap(err, "failed to parse peer count")
+	} else {
+		evmInfo.PeerCount = &count
+	}
+
+	return
+}
+
+// parsePeerCount parses the result of `net_peerCount`, which is a hex string on geth
+// but a plain number on Ethermint-based chains.
+func parsePeerCount(raw json.RawMessage) (int64, error) {
+	var hexCount hexutil.Uint64
+	if err := json.Unmarshal(raw, &hexCount); err == nil {
+		return int64(hexCount), nil
+	}
+
+	var count int64
+	if err := json.Unmarshal(raw, &count); err != nil {
+		return 0, fmt.Errorf("require hex string or number: %s", string(raw))
+	}
+	return count, nil
+}
+
+func fetchNodeRestInfo(rest string, timeout time.Duration) (*nodeRestInfo, error) {
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+
+	resp, err := httpClient.Get(rest + "/cosmos/base/tendermint/v1beta1/node_info")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch node info")
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch node info! Status code: %d", resp.StatusCode)
+	}
+
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body of node info")
+	}
+
+	type responseStruct struct {
+		DefaultNodeInfo struct {
+			DefaultNodeId string `json:"default_node_id"`
+			Network       string `json:"network"`
+			Version       string `json:"version"`
+			Moniker       string `json:"moniker"`
+		} `json:"default_node_info"`
+		ApplicationVersion struct {
+			Name             string `json:"name"`
+			AppName          string `json:"app_name"`
+			Version          string `json:"version"`
+			CosmosSdkVersion string `json:"cosmos_sdk_version"`
+		} `json:"application_version"`
+	}
+
+	var response responseStruct
+	if err := json.Unmarshal(bz, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response body of node info")
+	}
+
+	return &nodeRestInfo{
+		Endpoint:         rest,
+		NodeId:           response.DefaultNodeInfo.DefaultNodeId,
+		Moniker:          response.DefaultNodeInfo.Moniker,
+		Network:          response.DefaultNodeInfo.Network,
+		Version:          response.DefaultNodeInfo.Version,
+		AppName:          response.ApplicationVersion.AppName,
+		AppVersion:       response.ApplicationVersion.Version,
+		CosmosSdkVersion: response.ApplicationVersion.CosmosSdkVersion,
+	}, nil
+}
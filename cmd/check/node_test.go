@@ -0,0 +1,35 @@
+package check
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeerCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    int64
+		wantErr bool
+	}{
+		{name: "hex string (geth)", raw: `"0x19"`, want: 25},
+		{name: "zero hex string", raw: `"0x0"`, want: 0},
+		{name: "number (Ethermint)", raw: `25`, want: 25},
+		{name: "zero number", raw: `0`, want: 0},
+		{name: "decimal string", raw: `"25"`, wantErr: true},
+		{name: "object", raw: `{}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePeerCount(json.RawMessage(tt.raw))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
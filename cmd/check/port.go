@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/bcdevtools/devd/v3/cmd/flags"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	psnet "github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
@@ -12,25 +14,60 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+const (
+	flagWaitOpen   = "wait-open"
+	flagWaitClosed = "wait-closed"
+)
+
 func GetCheckPortCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "port [?port]",
 		Short: `List ports
 or check specific port currently open and holding by a process.`,
+		Long: `List ports
+or check specific port currently open and holding by a process.
+Use --wait-open or --wait-closed to wait until the port is open or closed before checking.`,
 		Args: cobra.RangeArgs(0, 1),
-		Run: func(_ *cobra.Command, args []string) {
+		Run: func(cmd *cobra.Command, args []string) {
+			outputFormat := flags.ReadFlagOutputFormat(cmd)
 			if len(args) == 0 {
-				listPorts()
+				if cmd.Flags().Changed(flagWaitOpen) || cmd.Flags().Changed(flagWaitClosed) {
+					utils.PrintlnStdErr("ERR: port is required to wait")
+					os.Exit(1)
+				}
+				listPorts(outputFormat)
 			} else {
-				checkPort(args[0])
+				port32 := mustReadPort(args[0])
+				mustWaitPort(cmd, port32)
+				checkPort(port32, outputFormat)
 			}
 		},
 	}
 
+	cmd.Flags().Bool(flagWaitOpen, false, "wait until the port is open")
+	cmd.Flags().Bool(flagWaitClosed, false, "wait until the port is closed")
+	cmd.Flags().Duration(flagTimeout, 2*time.Minute, "timeout of waiting, exit with non-zero code if reached")
+	cmd.Flags().Duration(flagInterval, time.Second, "interval between checks while waiting")
+
 	return cmd
 }
 
-func listPorts() {
+type portOutput struct {
+	Port       uint32  `json:"port"`
+	Status     string  `json:"status"`
+	Pid        int32   `json:"pid"`
+	Process    string  `json:"process,omitempty"`
+	ProcessCli string  `json:"processCli,omitempty"`
+	LocalIp    string  `json:"localIp"`
+	RemoteIp   string  `json:"remoteIp,omitempty"`
+	RemotePort uint32  `json:"remotePort,omitempty"`
+	Fd         uint32  `json:"fd"`
+	Family     uint32  `json:"family"`
+	Type       uint32  `json:"type"`
+	Uids       []int32 `json:"uids"`
+}
+
+func listPorts(outputFormat utils.OutputFormat) {
 	connections, err := psnet.Connections("all")
 	utils.ExitOnErr(err, "failed to get connections")
 
@@ -38,11 +75,8 @@ func listPorts() {
 		return l.Laddr.Port < r.Laddr.Port
 	})
 
+	ports := make([]portOutput, 0, len(connections))
 	for _, conn := range connections {
-		fmt.Printf("%5d", conn.Laddr.Port)
-		fmt.Printf(" | %-11s", conn.Status)
-		fmt.Printf(" | PID %-5d", conn.Pid)
-
 		processName := "(ERR)"
 		proc, err := process.NewProcess(conn.Pid)
 		if err == nil && proc != nil {
@@ -51,21 +85,43 @@ func listPorts() {
 				processName = name
 			}
 		}
-		fmt.Printf(" | PN %-30s", processName)
 
-		remoteIP := "-"
-		remotePort := "-"
-		if conn.Raddr.Port != 0 || conn.Raddr.IP != "" {
-			remoteIP = conn.Raddr.IP
-			remotePort = fmt.Sprintf("%d", conn.Raddr.Port)
-		}
-		fmt.Printf(" | REMOTE %30s:%-5s", remoteIP, remotePort)
-
-		fmt.Println()
+		ports = append(ports, portOutput{
+			Port:       conn.Laddr.Port,
+			Status:     conn.Status,
+			Pid:        conn.Pid,
+			Process:    processName,
+			LocalIp:    conn.Laddr.IP,
+			RemoteIp:   conn.Raddr.IP,
+			RemotePort: conn.Raddr.Port,
+			Fd:         conn.Fd,
+			Family:     conn.Family,
+			Type:       conn.Type,
+			Uids:       conn.Uids,
+		})
 	}
+
+	utils.PrintOutput(outputFormat, ports, func() {
+		for _, port := range ports {
+			fmt.Printf("%5d", port.Port)
+			fmt.Printf(" | %-11s", port.Status)
+			fmt.Printf(" | PID %-5d", port.Pid)
+			fmt.Printf(" | PN %-30s", port.Process)
+
+			remoteIP := "-"
+			remotePort := "-"
+			if port.RemotePort != 0 || port.RemoteIp != "" {
+				remoteIP = port.RemoteIp
+				remotePort = fmt.Sprintf("%d", port.RemotePort)
+			}
+			fmt.Printf(" | REMOTE %30s:%-5s", remoteIP, remotePort)
+
+			fmt.Println()
+		}
+	})
 }
 
-func checkPort(portStr string) {
+func mustReadPort(portStr string) uint32 {
 	port64, err := strconv.ParseInt(portStr, 10, 64)
 	utils.ExitOnErr(err, "failed to read, port is not a number")
 
@@ -74,22 +130,84 @@ func checkPort(portStr string) {
 		os.Exit(1)
 	}
 
-	var isOpen, anyErr bool
-
-	port32 := uint32(port64)
+	return uint32(port64)
+}
 
+// findPortConnection returns the first connection of the local port, nil if the port is not open.
+func findPortConnection(port32 uint32) (*psnet.ConnectionStat, error) {
 	connections, err := psnet.Connections("all")
-	utils.ExitOnErr(err, "failed to get connections")
+	if err != nil {
+		return nil, err
+	}
 
 	for _, conn := range connections {
-		if conn.Laddr.Port != port32 {
-			continue
+		if conn.Laddr.Port == port32 {
+			return &conn, nil
 		}
+	}
+
+	return nil, nil
+}
+
+// mustWaitPort waits until the port is open or closed, depends on the provided flag, exit if timeout reached.
+func mustWaitPort(cmd *cobra.Command, port32 uint32) {
+	waitOpen := cmd.Flags().Changed(flagWaitOpen)
+	waitClosed := cmd.Flags().Changed(flagWaitClosed)
+	if !waitOpen && !waitClosed {
+		return
+	}
+	if waitOpen && waitClosed {
+		utils.PrintlnStdErr("ERR: can not use both --wait-open and --wait-closed")
+		os.Exit(1)
+	}
+
+	timeout, err := cmd.Flags().GetDuration(flagTimeout)
+	utils.ExitOnErr(err, "failed to read timeout")
 
-		isOpen = true
+	interval, err := cmd.Flags().GetDuration(flagInterval)
+	utils.ExitOnErr(err, "failed to read interval")
+	if interval <= 0 {
+		utils.PrintlnStdErr("ERR: interval must be positive")
+		os.Exit(1)
+	}
 
-		fmt.Println(conn.Status)
-		fmt.Println("PID:", conn.Pid)
+	expectation := "open"
+	if waitClosed {
+		expectation = "closed"
+	}
+	utils.PrintlnStdErr("INF: waiting for port", port32, "to be", expectation)
+
+	ready := utils.WaitUntil(timeout, interval, func() bool {
+		conn, err := findPortConnection(port32)
+		utils.ExitOnErr(err, "failed to get connections")
+		return (conn != nil) == waitOpen
+	})
+	if !ready {
+		utils.PrintlnStdErr("ERR: port", port32, "is not", expectation, "after", timeout)
+		os.Exit(1)
+	}
+}
+
+func checkPort(port32 uint32, outputFormat utils.OutputFormat) {
+	var anyErr bool
+	var output *portOutput
+
+	conn, err := findPortConnection(port32)
+	utils.ExitOnErr(err, "failed to get connections")
+
+	if conn != nil {
+		output = &portOutput{
+			Port:       conn.Laddr.Port,
+			Status:     conn.Status,
+			Pid:        conn.Pid,
+			LocalIp:    conn.Laddr.IP,
+			RemoteIp:   conn.Raddr.IP,
+			RemotePort: conn.Raddr.Port,
+			Fd:         conn.Fd,
+			Family:     conn.Family,
+			Type:       conn.Type,
+			Uids:       conn.Uids,
+		}
 
 		if conn.Pid > 0 {
 			proc, err := process.NewProcess(conn.Pid)
@@ -99,39 +217,52 @@ func checkPort(portStr string) {
 			} else {
 				name, err := proc.Name()
 				if err == nil {
-					fmt.Println("PROC:", name)
+					output.Process = name
 				} else {
 					utils.PrintlnStdErr("ERR: Failed to get process name")
 					anyErr = true
 				}
 				cmdLine, err := proc.Cmdline()
 				if err == nil {
-					fmt.Println("PROC CLI:", cmdLine)
+					output.ProcessCli = cmdLine
 				} else {
 					utils.PrintlnStdErr("ERR: Failed to get process command line")
 					anyErr = true
 				}
 			}
 		}
+	}
+
+	utils.PrintOutput(outputFormat, map[string]any{
+		"port":   port32,
+		"isOpen": output != nil,
+		"detail": output,
+	}, func() {
+		if output == nil {
+			fmt.Println("Not open")
+			return
+		}
 
-		fmt.Println("LOCAL IP:", conn.Laddr.IP)
+		fmt.Println(output.Status)
+		fmt.Println("PID:", output.Pid)
+		if output.Process != "" {
+			fmt.Println("PROC:", output.Process)
+		}
+		if output.ProcessCli != "" {
+			fmt.Println("PROC CLI:", output.ProcessCli)
+		}
+		fmt.Println("LOCAL IP:", output.LocalIp)
 		fmt.Print("REMOTE: ")
-		if conn.Raddr.Port == 0 && conn.Raddr.IP == "" {
+		if output.RemotePort == 0 && output.RemoteIp == "" {
 			fmt.Println("NONE")
 		} else {
-			fmt.Println(conn.Raddr.IP, " ", conn.Raddr.Port)
+			fmt.Println(output.RemoteIp, " ", output.RemotePort)
 		}
-		fmt.Println("FD:", conn.Fd)
-		fmt.Println("FAMILY:", conn.Family)
-		fmt.Println("TYPE:", conn.Type)
-		fmt.Println("UIDs:", conn.Uids)
-
-		break
-	}
-
-	if !isOpen {
-		fmt.Println("Not open")
-	}
+		fmt.Println("FD:", output.Fd)
+		fmt.Println("FAMILY:", output.Family)
+		fmt.Println("TYPE:", output.Type)
+		fmt.Println("UIDs:", output.Uids)
+	})
 
 	if anyErr {
 		os.Exit(1)
@@ -14,6 +14,8 @@ func Commands() *cobra.Command {
 
 	cmd.AddCommand(
 		GetCheckPortCommand(),
+		GetCheckNodeCommand(),
+		GetCheckWaitCommand(),
 	)
 
 	return cmd
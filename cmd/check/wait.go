@@ -0,0 +1,168 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagMinHeight = "min-height"
+	flagInterval  = "interval"
+)
+
+type waitEndpoint struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+	Height   int64  `json:"height"`
+
+	check         func(ctx context.Context, endpoint string) (int64, error)
+	ready         bool
+	lastStatus    string
+	lastStatusKey string
+}
+
+type waitOutput struct {
+	Ready     bool            `json:"ready"`
+	Elapsed   string          `json:"elapsed"`
+	Endpoints []*waitEndpoint `json:"endpoints"`
+}
+
+func GetCheckWaitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until the endpoints are responding and the chain produced blocks",
+		Long: `Wait until the requested endpoints are responding and the chain produced blocks, can be used in devnet scripts.
+Endpoints are requested via --evm-rpc, --tm-rpc and --rest flags, flag without value uses the one from environment variable, network profile or default.
+Flag with value must be provided in form --flag=value.
+If none of them provided, all the three endpoints are waited.
+The latest height reported by each endpoint must reach --min-height, default 1 means at least one block produced.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			minHeight, err := cmd.Flags().GetInt64(flagMinHeight)
+			utils.ExitOnErr(err, "failed to read min height")
+			if minHeight < 1 {
+				utils.PrintlnStdErr("ERR: min height must be at least 1")
+				os.Exit(1)
+			}
+
+			timeout, err := cmd.Flags().GetDuration(flagTimeout)
+			utils.ExitOnErr(err, "failed to read timeout")
+
+			interval, err := cmd.Flags().GetDuration(flagInterval)
+			utils.ExitOnErr(err, "failed to read interval")
+			if interval <= 0 {
+				utils.PrintlnStdErr("ERR: interval must be positive")
+				os.Exit(1)
+			}
+
+			waitAll := !cmd.Flags().Changed(flags.FlagEvmRpc) && !cmd.Flags().Changed(flags.FlagTendermintRpc) && !cmd.Flags().Changed(flags.FlagCosmosRest)
+
+			var endpoints []*waitEndpoint
+			if waitAll || cmd.Flags().Changed(flags.FlagTendermintRpc) {
+				tmRpc, _ := flags.ReadTmRpc(cmd)
+				endpoints = append(endpoints, &waitEndpoint{
+					Name:     "tm-rpc",
+					Endpoint: tmRpc,
+					check:    utils.CheckTmRpcReady,
+				})
+			}
+			if waitAll || cmd.Flags().Changed(flags.FlagEvmRpc) {
+				evmRpc, _ := flags.ReadEvmRpc(cmd)
+				endpoints = append(endpoints, &waitEndpoint{
+					Name:     "evm-rpc",
+					Endpoint: evmRpc,
+					check:    utils.CheckEvmRpcReady,
+				})
+			}
+			if waitAll || cmd.Flags().Changed(flags.FlagCosmosRest) {
+				rest, _ := flags.ReadCosmosRest(cmd)
+				endpoints = append(endpoints, &waitEndpoint{
+					Name:     "rest",
+					Endpoint: rest,
+					check:    utils.CheckCosmosRestReady,
+				})
+			}
+
+			for _, endpoint := range endpoints {
+				utils.PrintlnStdErr("INF: waiting for", endpoint.Name, endpoint.Endpoint)
+			}
+
+			startTime := time.Now()
+			ready := utils.WaitUntil(timeout, interval, func() bool {
+				allReady := true
+				for _, endpoint := range endpoints {
+					if endpoint.ready {
+						continue
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), interval+5*time.Second)
+					height, err := endpoint.check(ctx, endpoint.Endpoint)
+					cancel()
+
+					var status, statusKey string
+					if err != nil {
+						status = err.Error()
+						statusKey = status
+					} else if height >= 0 && height < minHeight {
+						status = fmt.Sprintf("height %d has not reached %d", height, minHeight)
+						statusKey = flagMinHeight // do not print on every new block
+					} else {
+						endpoint.ready = true
+						endpoint.Height = height
+						if height >= 0 {
+							utils.PrintlnStdErr("INF:", endpoint.Name, "is ready", fmt.Sprintf("(height %d)", height))
+						} else {
+							utils.PrintlnStdErr("INF:", endpoint.Name, "is ready")
+						}
+						continue
+					}
+
+					allReady = false
+					if statusKey != endpoint.lastStatusKey {
+						utils.PrintlnStdErr("INF:", endpoint.Name, "is not ready:", status)
+					}
+					endpoint.lastStatusKey = statusKey
+					endpoint.lastStatus = status
+				}
+				return allReady
+			})
+
+			output := waitOutput{
+				Ready:     ready,
+				Elapsed:   time.Since(startTime).Round(time.Millisecond).String(),
+				Endpoints: endpoints,
+			}
+
+			if !ready {
+				for _, endpoint := range endpoints {
+					if !endpoint.ready {
+						utils.PrintlnStdErr("ERR:", endpoint.Name, endpoint.Endpoint, "is not ready after", timeout, ":", endpoint.lastStatus)
+					}
+				}
+				os.Exit(1)
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Ready after", output.Elapsed)
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagTendermintRpc, "", flags.FlagTmRpcDesc)
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+	cmd.Flags().Lookup(flags.FlagTendermintRpc).NoOptDefVal = " "
+	cmd.Flags().Lookup(flags.FlagEvmRpc).NoOptDefVal = " "
+	cmd.Flags().Lookup(flags.FlagCosmosRest).NoOptDefVal = " "
+	cmd.Flags().Int64(flagMinHeight, 1, "wait until the latest height of each endpoint reached this height, at least 1 so the chain has produced blocks")
+	cmd.Flags().Duration(flagTimeout, 2*time.Minute, "give up and exit with non-zero code after this duration")
+	cmd.Flags().Duration(flagInterval, time.Second, "interval between checks")
+
+	return cmd
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagBech32Hrp = "bech32-hrp"
+	flagChainId   = "chain-id"
+	flagUse       = "use"
+	flagOverwrite = "overwrite"
+)
+
+func GetConfigAddNetworkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [name]",
+		Short: "Add a named network profile into config file",
+		Long: fmt.Sprintf(`Add a named network profile into config file.
+The profile can be used by providing global flag '--%s <name>' or selected as default via 'config use <name>'.`, flags.FlagNetwork),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			utils.ExitOnErr(types.ValidateNetworkProfileName(name), "invalid network profile name")
+
+			config, configFile := mustLoadConfig()
+
+			if _, found := config.GetNetworkProfile(name); found && !cmd.Flags().Changed(flagOverwrite) {
+				utils.PrintfStdErr("ERR: network profile [%s] already exists, use flag '--%s' to replace it\n", name, flagOverwrite)
+				os.Exit(1)
+			}
+
+			readFlag := func(flag string) string {
+				value, _ := cmd.Flags().GetString(flag)
+				return strings.TrimSpace(value)
+			}
+
+			profile := types.NetworkProfile{
+				EvmRpc:     strings.TrimSuffix(readFlag(flags.FlagEvmRpc), "/"),
+				TmRpc:      strings.TrimSuffix(readFlag(flags.FlagTendermintRpc), "/"),
+				CosmosRest: strings.TrimSuffix(readFlag(flags.FlagCosmosRest), "/"),
+				Bech32Hrp:  strings.TrimSuffix(strings.ToLower(readFlag(flagBech32Hrp)), "1"),
+				ChainId:    readFlag(flagChainId),
+				GasPrices:  readFlag(flags.FlagGasPrices),
+				GasLimit:   readFlag(flags.FlagGasLimit),
+			}
+
+			if profile.ChainId != "" {
+				chainId, err := utils.ReadShortIntOrHex(profile.ChainId)
+				utils.ExitOnErr(err, "invalid EVM chain ID")
+				if chainId.Sign() < 1 {
+					utils.PrintlnStdErr("ERR: EVM chain ID must be positive")
+					os.Exit(1)
+				}
+				profile.ChainId = chainId.String() // persist in decimal, the format compared by tx commands
+			}
+			if profile == (types.NetworkProfile{}) {
+				utils.PrintlnStdErr("WARN: adding an empty network profile")
+			}
+
+			config.SetNetworkProfile(name, profile)
+			if cmd.Flags().Changed(flagUse) || len(config.Networks) == 1 {
+				config.Network = name
+			}
+
+			mustSaveConfig(config, configFile)
+
+			utils.PrintlnStdErr("INF: Network profile", name, "saved into", configFile)
+			if config.Network == name {
+				utils.PrintlnStdErr("INF: Network profile", name, "is now in use by default")
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", "EVM Json-RPC endpoint")
+	cmd.Flags().String(flags.FlagTendermintRpc, "", "Tendermint RPC endpoint")
+	cmd.Flags().String(flags.FlagCosmosRest, "", "Cosmos Rest API endpoint")
+	cmd.Flags().String(flagBech32Hrp, "", "Bech32 HRP of account address, eg: ethm")
+	cmd.Flags().String(flagChainId, "", "EVM chain ID, tx commands will refuse to work if EVM Json-RPC returns different chain ID")
+	cmd.Flags().String(flags.FlagGasPrices, "", "default gas prices for tx commands, support custom unit (eg: 20b or 20g(wei))")
+	cmd.Flags().String(flags.FlagGasLimit, "", "default gas limit for tx commands, support custom unit (eg: 500k) or 'auto' to estimate")
+	cmd.Flags().Bool(flagUse, false, "use this network profile by default")
+	cmd.Flags().Bool(flagOverwrite, false, "replace the network profile if it already exists")
+
+	return cmd
+}
+
+func GetConfigListNetworksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List network profiles in config file, the one in use by default is marked with '*'",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			config, configFile := mustLoadConfig()
+
+			names := config.SortedNetworkProfileNames()
+			if len(names) == 0 {
+				utils.PrintlnStdErr("INF: no network profile found in", configFile)
+				return
+			}
+
+			type profileOutput struct {
+				Name  string `json:"name"`
+				InUse bool   `json:"inUse"`
+				types.NetworkProfile
+			}
+
+			profiles := make([]profileOutput, 0, len(names))
+			for _, name := range names {
+				profile, _ := config.GetNetworkProfile(name)
+				profiles = append(profiles, profileOutput{
+					Name:           name,
+					InUse:          name == config.Network,
+					NetworkProfile: profile,
+				})
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), profiles, func() {
+				orDash := func(str string) string {
+					if str == "" {
+						return "-"
+					}
+					return str
+				}
+
+				printRow := func(colInUse, colName, colEvmRpc, colTmRpc, colRest, colHrp, colChainId, colGasPrices, colGas string) {
+					fmt.Printf("%1s %-16s | %-32s | %-32s | %-32s | %-8s | %-10s | %-10s | %-8s\n", colInUse, colName, colEvmRpc, colTmRpc, colRest, colHrp, colChainId, colGasPrices, colGas)
+				}
+
+				printRow("", "Name", "EVM RPC", "TM RPC", "Rest", "HRP", "Chain ID", "Gas Prices", "Gas")
+				for _, profile := range profiles {
+					var inUse string
+					if profile.InUse {
+						inUse = "*"
+					}
+
+					printRow(inUse, profile.Name, orDash(profile.EvmRpc), orDash(profile.TmRpc), orDash(profile.CosmosRest), orDash(profile.Bech32Hrp), orDash(profile.ChainId), orDash(profile.GasPrices), orDash(profile.GasLimit))
+				}
+			})
+		},
+	}
+
+	return cmd
+}
+
+func GetConfigUseNetworkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use [name]",
+		Short: "Use the network profile by default when flag --network is not provided",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			config, configFile := mustLoadConfig()
+
+			if _, found := config.GetNetworkProfile(name); !found {
+				utils.PrintfStdErr("ERR: network profile [%s] does not exist\n", name)
+				os.Exit(1)
+			}
+
+			config.Network = name
+
+			mustSaveConfig(config, configFile)
+
+			utils.PrintlnStdErr("INF: Network profile", name, "is now in use by default")
+		},
+	}
+
+	return cmd
+}
+
+func GetConfigRemoveNetworkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove [name]",
+		Aliases: []string{"rm"},
+		Short:   "Remove the network profile from config file",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+
+			config, configFile := mustLoadConfig()
+
+			wasInUse := config.Network == name
+			if !config.RemoveNetworkProfile(name) {
+				utils.PrintfStdErr("ERR: network profile [%s] does not exist\n", name)
+				os.Exit(1)
+			}
+
+			mustSaveConfig(config, configFile)
+
+			utils.PrintlnStdErr("INF: Network profile", name, "removed")
+			if wasInUse {
+				utils.PrintlnStdErr("WARN: removed network profile was in use by default, no network profile is in use now")
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// Commands registers a sub-tree of commands
+func Commands() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		Aliases: []string{"cfg"},
+		Short:   "Manage network profiles in config file",
+	}
+
+	cmd.AddCommand(
+		GetConfigAddNetworkCommand(),
+		GetConfigListNetworksCommand(),
+		GetConfigUseNetworkCommand(),
+		GetConfigRemoveNetworkCommand(),
+	)
+
+	return cmd
+}
+
+func mustLoadConfig() (config *types.Config, configFile string) {
+	configFile, err := types.GetConfigFilePath()
+	utils.ExitOnErr(err, "failed to get config file path")
+
+	config, err = types.LoadConfig(configFile)
+	utils.ExitOnErr(err, "failed to load config")
+
+	return
+}
+
+func mustSaveConfig(config *types.Config, configFile string) {
+	err := types.SaveConfig(configFile, config)
+	utils.ExitOnErr(err, "failed to save config")
+}
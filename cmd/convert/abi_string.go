@@ -2,7 +2,6 @@ package convert
 
 import (
 	"encoding/hex"
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -34,7 +33,7 @@ Support pipe.`,
 					if len(str) == 0 {
 						return false, nil
 					}
-					fmt.Println(str)
+					printConvertOutput(cmd, args[0], str)
 					return true, nil
 				}
 				if regexp.MustCompile(`^0x[a-f\d]+$`).MatchString(args[0]) && (len(args[0])-2 /*exclude 0x*/)%64 == 0 {
@@ -52,9 +51,10 @@ Support pipe.`,
 				}
 			}
 
-			bz, err := utils.AbiEncodeString(strings.Join(args, " "))
+			input := strings.Join(args, " ")
+			bz, err := utils.AbiEncodeString(input)
 			utils.ExitOnErr(err, "failed to encode string to ABI hex")
-			fmt.Println("0x" + hex.EncodeToString(bz))
+			printConvertOutput(cmd, input, "0x"+hex.EncodeToString(bz))
 		},
 	}
 
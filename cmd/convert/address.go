@@ -51,7 +51,7 @@ func GetConvertAddressCmd() *cobra.Command {
 				bech32Addr, err := bech32.ConvertAndEncode(nextConvertToBech32Hrp, evmAddress.Bytes())
 				utils.ExitOnErr(err, "failed to convert EVM address to bech32 address")
 
-				fmt.Println(bech32Addr)
+				printConvertOutput(cmd, args[0], bech32Addr)
 
 				return
 			}
@@ -68,7 +68,7 @@ func GetConvertAddressCmd() *cobra.Command {
 				bech32Addr, err := bech32.ConvertAndEncode(nextConvertToBech32Hrp, bytesAddress.Bytes())
 				utils.ExitOnErr(err, "failed to convert bytes address to bech32 address")
 
-				fmt.Println(bech32Addr)
+				printConvertOutput(cmd, args[0], bech32Addr)
 
 				return
 			}
@@ -95,12 +95,12 @@ func GetConvertAddressCmd() *cobra.Command {
 					),
 				)
 
-				fmt.Println(bech32Addr)
+				printConvertOutput(cmd, args[0], bech32Addr)
 				return
 			}
 
 			// case 3
-			fmt.Printf("0x%x\n", bz)
+			printConvertOutput(cmd, args[0], fmt.Sprintf("0x%x", bz))
 		},
 	}
 
@@ -42,11 +42,12 @@ Support pipe.`, flagDecode),
 				if showBuffer {
 					utils.PrintfStdErr("(buffer: %s)\n", hex.EncodeToString(data))
 				}
-				fmt.Println(string(data))
+				printConvertOutput(cmd, args[0], string(data))
 			} else {
 				utils.RequireArgs(args, cmd)
 				utils.PrintfStdErr("INF: encoding base64 (use --%s to decode)\n", flagDecode)
-				data := []byte(strings.Join(args, " "))
+				input := strings.Join(args, " ")
+				data := []byte(input)
 				if fromBuffer {
 					data, err = hex.DecodeString(string(data))
 					utils.ExitOnErr(err, "failed to decode hex buffer")
@@ -54,7 +55,7 @@ Support pipe.`, flagDecode),
 				if showBuffer {
 					utils.PrintfStdErr("(buffer: %s)\n", hex.EncodeToString(data))
 				}
-				fmt.Println(base64.StdEncoding.EncodeToString(data))
+				printConvertOutput(cmd, input, base64.StdEncoding.EncodeToString(data))
 			}
 		},
 	}
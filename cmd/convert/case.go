@@ -28,12 +28,12 @@ Support pipe.`, flagToUpperCase),
 			input := strings.Join(args, " ")
 			if cmd.Flag(flagToUpperCase).Changed {
 				utils.PrintlnStdErr("INF: converting to upper case")
-				fmt.Println(strings.ToUpper(input))
+				printConvertOutput(cmd, input, strings.ToUpper(input))
 				return
 			}
 
 			utils.PrintfStdErr("INF: converting to lower case (use --%s to upper case)\n", flagToUpperCase)
-			fmt.Println(strings.ToLower(input))
+			printConvertOutput(cmd, input, strings.ToLower(input))
 		},
 	}
 
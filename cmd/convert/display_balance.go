@@ -1,7 +1,6 @@
 package convert
 
 import (
-	"fmt"
 	"math/big"
 	"os"
 	"strconv"
@@ -40,7 +39,7 @@ Support short int`,
 			display, _, _, err := utils.ConvertNumberIntoDisplayWithExponent(balance, int(decimals))
 			utils.ExitOnErr(err, "failed to convert raw balance into display balance")
 
-			fmt.Println(display)
+			printConvertOutput(cmd, rawBalanceStr, display)
 		},
 	}
 
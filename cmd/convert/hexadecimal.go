@@ -1,7 +1,6 @@
 package convert
 
 import (
-	"fmt"
 	"math/big"
 	"os"
 	"regexp"
@@ -24,7 +23,8 @@ Support pipe.`,
 			utils.ExitOnErr(err, "failed to get args from pipe")
 			utils.RequireExactArgsCount(args, 1, cmd)
 
-			input := strings.ToLower(args[0])
+			originalInput := args[0]
+			input := strings.ToLower(originalInput)
 
 			if regexp.MustCompile(`^0x[a-f\d]+$`).MatchString(input) { // input is hexadecimal with 0x prefix
 				input = strings.TrimPrefix(input, "0x")
@@ -36,7 +36,7 @@ Support pipe.`,
 				}
 
 				utils.PrintlnStdErr("INF: converting hexadecimal to decimal")
-				fmt.Println(bi)
+				printConvertOutput(cmd, originalInput, bi.String())
 				return
 			}
 
@@ -53,7 +53,7 @@ Support pipe.`,
 					os.Exit(1)
 				}
 
-				fmt.Println("0x" + bi.Text(16))
+				printConvertOutput(cmd, originalInput, "0x"+bi.Text(16))
 				return
 			}
 
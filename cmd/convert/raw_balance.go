@@ -1,7 +1,6 @@
 package convert
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 
@@ -41,7 +40,7 @@ Sample: 10.0111 with 6 exponent => 10011100`,
 			raw, _, _, err := utils.ConvertDisplayWithExponentIntoRaw(displayBalanceStr, int(decimals), rune(decimalsPoint[0]))
 			utils.ExitOnErr(err, "failed to convert display balance into raw balance")
 
-			fmt.Println(raw)
+			printConvertOutput(cmd, displayBalanceStr, raw.String())
 		},
 	}
 
@@ -1,6 +1,10 @@
 package convert
 
 import (
+	"fmt"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,3 +29,13 @@ func Commands() *cobra.Command {
 
 	return cmd
 }
+
+// printConvertOutput prints the converted output as is, or together with the input in JSON/YAML format.
+func printConvertOutput(cmd *cobra.Command, input, output string) {
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]string{
+		"input":  input,
+		"output": output,
+	}, func() {
+		fmt.Println(output)
+	})
+}
@@ -0,0 +1,39 @@
+package debug
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+func GetCosmosTxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cosmos-tx [raw protobuf-encoded Cosmos tx base64 or hex]",
+		Short: "Decode the raw protobuf-encoded Cosmos tx to see messages, fee, signer infos, memo and timeout height",
+		Long: `Decode the raw protobuf-encoded Cosmos tx (TxRaw) to see messages, fee, signer infos, memo and timeout height.
+MsgEthereumTx is unwrapped into the inner EVM tx, additional information will be injected with prefix '_'.
+Messages of unknown type are shown as type URL and base64 encoded value.
+Support pipe.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			args, err := utils.ProvidedArgsOrFromPipe(args)
+			utils.ExitOnErr(err, "failed to get args from pipe")
+			utils.RequireExactArgsCount(args, 1, cmd)
+
+			bz, err := utils.DecodeRawCosmosTxInput(strings.TrimSpace(args[0]))
+			utils.ExitOnErr(err, "failed to decode input")
+
+			tx, err := utils.DecodeCosmosTx(bz)
+			utils.ExitOnErr(err, "failed to decode into Cosmos tx")
+
+			bz, err = tx.MarshalPrettyJson()
+			utils.ExitOnErr(err, "failed to marshal tx to json")
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
+		},
+	}
+
+	return cmd
+}
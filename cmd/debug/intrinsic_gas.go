@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/bcdevtools/devd/v3/cmd/flags"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -25,7 +26,7 @@ This operation assumes:
 - EIP-2028 (Istanbul)
 - The transaction is not a contract creation transaction, if it is, need to plus %d into the output to have the correct number`, params.TxGasContractCreation-params.TxGas),
 		Args: cobra.ExactArgs(1),
-		Run: func(_ *cobra.Command, args []string) {
+		Run: func(cmd *cobra.Command, args []string) {
 			input := strings.ToLower(args[0])
 			if !regexp.MustCompile(`^(0x)?[a-f\d]+$`).MatchString(input) {
 				utils.PrintlnStdErr("ERR: invalid EVM transaction input data format")
@@ -51,14 +52,20 @@ This operation assumes:
 			}
 			intrinsicGas := getIntrinsicGasFromInputData(bz)
 
-			fmt.Println("Zero byte count:", zeroByteCount)
-			fmt.Println("Non-zero byte count:", nonZeroByteCount)
-			fmt.Println("Intrinsic gas:", intrinsicGas)
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]any{
+				"zeroByteCount":    zeroByteCount,
+				"nonZeroByteCount": nonZeroByteCount,
+				"intrinsicGas":     intrinsicGas,
+			}, func() {
+				fmt.Println("Zero byte count:", zeroByteCount)
+				fmt.Println("Non-zero byte count:", nonZeroByteCount)
+				fmt.Println("Intrinsic gas:", intrinsicGas)
 
-			recompute := params.TxGas + params.TxDataNonZeroGasEIP2028*uint64(nonZeroByteCount) + params.TxDataZeroGas*uint64(zeroByteCount)
-			if recompute == intrinsicGas {
-				fmt.Println("=", "tx gas", params.TxGas, "+", "non-zero byte gas", params.TxDataNonZeroGasEIP2028, "x", nonZeroByteCount, "+", "zero byte gas", params.TxDataZeroGas, "x", zeroByteCount)
-			}
+				recompute := params.TxGas + params.TxDataNonZeroGasEIP2028*uint64(nonZeroByteCount) + params.TxDataZeroGas*uint64(zeroByteCount)
+				if recompute == intrinsicGas {
+					fmt.Println("=", "tx gas", params.TxGas, "+", "non-zero byte gas", params.TxDataNonZeroGasEIP2028, "x", nonZeroByteCount, "+", "zero byte gas", params.TxDataZeroGas, "x", zeroByteCount)
+				}
+			})
 		},
 	}
 
@@ -1,11 +1,12 @@
 package debug
 
 import (
-	"fmt"
+	"encoding/json"
 	"os"
 	"regexp"
 	"strings"
 
+	"github.com/bcdevtools/devd/v3/cmd/flags"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +32,7 @@ func GetRawTxCommand() *cobra.Command {
 			})
 			utils.ExitOnErr(err, "failed to marshal tx to json")
 
-			fmt.Println(string(bz))
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
 		},
 	}
 
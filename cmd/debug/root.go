@@ -15,6 +15,7 @@ func Commands() *cobra.Command {
 	cmd.AddCommand(
 		GetIntrinsicCommand(),
 		GetRawTxCommand(),
+		GetCosmosTxCommand(),
 	)
 
 	return cmd
@@ -0,0 +1,95 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagAbi    = "abi"
+	FlagMethod = "method"
+)
+
+const (
+	FlagAbiDesc    = "path to ABI file, either ABI array or artifact contains `abi` field (Hardhat, Foundry,...)"
+	FlagMethodDesc = "name or signature of the method in the ABI file provided via --" + FlagAbi
+)
+
+// ReadFlagAbiOrNil reads the ABI file provided via flag --abi.
+// Returns nil if the flag is not provided.
+func ReadFlagAbiOrNil(cmd *cobra.Command) (*abi.ABI, error) {
+	if cmd.Flags().Lookup(FlagAbi) == nil {
+		return nil, nil
+	}
+
+	abiFile, _ := cmd.Flags().GetString(FlagAbi)
+	if abiFile == "" {
+		return nil, nil
+	}
+
+	contractAbi, err := utils.ReadAbiFile(abiFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contractAbi, nil
+}
+
+// ReadAbiMethodAndArgs resolves the method to be called and its arguments:
+//   - If flag --method is provided, the method will be looked up from the ABI file provided via --abi,
+//     all the args are arguments of the method.
+//   - Otherwise, the first arg is the method signature like `balanceOf(address)(uint256)`,
+//     the remaining args are arguments of the method.
+func ReadAbiMethodAndArgs(cmd *cobra.Command, args []string) (method abi.Method, methodArgs []string, err error) {
+	var methodName string
+	if cmd.Flags().Lookup(FlagMethod) != nil {
+		methodName, _ = cmd.Flags().GetString(FlagMethod)
+	}
+
+	if methodName == "" {
+		if len(args) < 1 {
+			err = fmt.Errorf("require method signature or flag --%s", FlagMethod)
+			return
+		}
+
+		method, err = utils.ParseMethodSignature(args[0])
+		methodArgs = args[1:]
+		return
+	}
+
+	contractAbi, err := ReadFlagAbiOrNil(cmd)
+	if err != nil {
+		return
+	}
+	if contractAbi == nil {
+		err = fmt.Errorf("flag --%s requires flag --%s", FlagMethod, FlagAbi)
+		return
+	}
+
+	methodArgs = args
+
+	if found, ok := contractAbi.Methods[methodName]; ok {
+		method = found
+		return
+	}
+
+	// lookup by signature, useful for overloaded methods
+	var availableMethods []string
+	normalizedSignature := strings.ReplaceAll(methodName, " ", "")
+	for _, candidate := range contractAbi.Methods {
+		if candidate.Sig == normalizedSignature {
+			method = candidate
+			return
+		}
+		availableMethods = append(availableMethods, candidate.Sig)
+	}
+
+	sort.Strings(availableMethods)
+	err = fmt.Errorf("method %s not found in ABI, available methods: %s", methodName, strings.Join(availableMethods, ", "))
+	return
+}
@@ -2,24 +2,29 @@ package flags
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"os"
-	"regexp"
 	"strings"
 
+	"github.com/bcdevtools/devd/v3/cmd/types"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/bcdevtools/devd/v3/constants"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 const (
-	FlagSecretKey = "secret-key"
+	FlagSecretKey      = "secret-key"
+	FlagFrom           = "from"
+	FlagPassphraseFile = "passphrase-file"
 )
 
 const (
-	FlagSecretKeyDesc = "Secret private key or mnemonic of the account, can be set by environment variable " + constants.ENV_SECRET_KEY
+	FlagSecretKeyDesc      = "Secret private key or mnemonic of the account, can be set by environment variable " + constants.ENV_SECRET_KEY
+	FlagFromDesc           = "Name of the key in keystore to sign with, keys can be managed via 'keys' commands"
+	FlagPassphraseFileDesc = "File contains the passphrase of the key, the passphrase will be prompted if not provided"
 )
 
 func MustSecretEvmAccount(cmd *cobra.Command) (ecdsaPrivateKey *ecdsa.PrivateKey, ecdsaPubKey *ecdsa.PublicKey, account *common.Address) {
@@ -27,7 +32,10 @@ func MustSecretEvmAccount(cmd *cobra.Command) (ecdsaPrivateKey *ecdsa.PrivateKey
 	var err error
 	var ok bool
 
-	if secretFromFlag, _ := cmd.Flags().GetString(FlagSecretKey); len(secretFromFlag) > 0 {
+	if keyName := readFlagFromKeyName(cmd); keyName != "" {
+		ecdsaPrivateKey = mustDecryptKeyFromKeyStore(cmd, keyName)
+		inputSource = fmt.Sprintf("key %s", keyName)
+	} else if secretFromFlag, _ := cmd.Flags().GetString(FlagSecretKey); len(secretFromFlag) > 0 {
 		secret = secretFromFlag
 		inputSource = "flag"
 	} else if secretFromEnv := os.Getenv(constants.ENV_SECRET_KEY); len(secretFromEnv) > 0 {
@@ -35,33 +43,20 @@ func MustSecretEvmAccount(cmd *cobra.Command) (ecdsaPrivateKey *ecdsa.PrivateKey
 		inputSource = "environment variable"
 	} else {
 		utils.PrintlnStdErr("ERR: secret key is required")
-		utils.PrintfStdErr("ERR: secret key can be set by flag '--%s <your_secret_key>' or environment variable 'export %s=<your_secret_key>'.\n", FlagSecretKey, constants.ENV_SECRET_KEY)
+		utils.PrintfStdErr("ERR: secret key can be set by flag '--%s <key_name>', '--%s <your_secret_key>' or environment variable 'export %s=<your_secret_key>'.\n", FlagFrom, FlagSecretKey, constants.ENV_SECRET_KEY)
 		os.Exit(1)
 	}
 
-	if regexp.MustCompile(`^(0x)?[a-fA-F\d]{64}$`).MatchString(secret) {
-		// private key
-		privKey := strings.TrimPrefix(secret, "0x")
-
-		pKeyBytes, err := hexutil.Decode("0x" + privKey)
-		if err != nil {
-			utils.PrintlnStdErr("ERR: failed to decode private key")
-			os.Exit(1)
+	if ecdsaPrivateKey == nil {
+		hdPath, bip39Passphrase := MustReadHdDerivationFlags(cmd)
+		if IsHdDerivationFlagsChanged(cmd) && len(strings.Fields(secret)) < 2 {
+			utils.PrintlnStdErr("WARN: HD derivation flags are ignored because the secret key is not a mnemonic")
 		}
 
-		ecdsaPrivateKey, err = crypto.ToECDSA(pKeyBytes)
-		if err != nil {
-			utils.PrintlnStdErr("ERR: failed to convert private key to ECDSA")
-			os.Exit(1)
-		}
-	} else if mnemonicCount := len(strings.Split(secret, " ")); mnemonicCount == 12 || mnemonicCount == 24 {
-		// is mnemonic
-		mnemonic := secret
-		ecdsaPrivateKey, err = utils.FromMnemonicToPrivateKey(mnemonic, "" /*no password protected*/)
-		utils.ExitOnErr(err, "failed to convert mnemonic to private key")
-	} else {
-		utils.PrintlnStdErr("ERR: invalid secret key format")
-		os.Exit(1)
+		ecdsaPrivateKey, err = utils.FromSecretToPrivateKey(secret, bip39Passphrase, hdPath)
+		utils.ExitOnErr(err, "failed to read secret key")
+	} else if IsHdDerivationFlagsChanged(cmd) {
+		utils.PrintlnStdErr("WARN: HD derivation flags are ignored when signing with a stored key")
 	}
 
 	publicKey := ecdsaPrivateKey.Public()
@@ -78,3 +73,73 @@ func MustSecretEvmAccount(cmd *cobra.Command) (ecdsaPrivateKey *ecdsa.PrivateKey
 
 	return
 }
+
+// AddSecretEvmAccountFlags registers flags used by MustSecretEvmAccount.
+func AddSecretEvmAccountFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagSecretKey, "", FlagSecretKeyDesc)
+	cmd.Flags().String(FlagFrom, "", FlagFromDesc)
+	cmd.Flags().String(FlagPassphraseFile, "", FlagPassphraseFileDesc)
+	AddHdDerivationFlags(cmd)
+}
+
+func readFlagFromKeyName(cmd *cobra.Command) string {
+	if cmd.Flags().Lookup(FlagFrom) == nil {
+		return ""
+	}
+	keyName, _ := cmd.Flags().GetString(FlagFrom)
+	return strings.TrimSpace(keyName)
+}
+
+// MustGetKeyStore returns the keystore at the default location, ~/.devd/keys
+func MustGetKeyStore() *types.KeyStore {
+	keysDir, err := types.GetKeyStoreDirPath()
+	utils.ExitOnErr(err, "failed to get keys directory")
+	return types.NewKeyStore(keysDir)
+}
+
+func mustDecryptKeyFromKeyStore(cmd *cobra.Command, keyName string) *ecdsa.PrivateKey {
+	keyStore := MustGetKeyStore()
+	if !keyStore.Has(keyName) {
+		utils.PrintfStdErr("ERR: key [%s] does not exist, keys can be managed via 'keys' commands\n", keyName)
+		os.Exit(1)
+	}
+
+	passphrase, err := ReadPassphrase(cmd, fmt.Sprintf("Enter passphrase of key [%s]", keyName), false)
+	utils.ExitOnErr(err, "failed to read passphrase")
+
+	ecdsaPrivateKey, err := keyStore.Decrypt(keyName, passphrase)
+	utils.ExitOnErr(err, "failed to decrypt key")
+
+	return ecdsaPrivateKey
+}
+
+// ReadPassphrase reads the passphrase from the file provided via flag --passphrase-file,
+// or prompts for the passphrase if the flag is not provided. Passphrase will be prompted twice if confirm is true.
+func ReadPassphrase(cmd *cobra.Command, prompt string, confirm bool) (string, error) {
+	if cmd.Flags().Lookup(FlagPassphraseFile) != nil {
+		if passphraseFile, _ := cmd.Flags().GetString(FlagPassphraseFile); passphraseFile != "" {
+			bz, err := os.ReadFile(passphraseFile)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to read passphrase file")
+			}
+			return strings.TrimRight(string(bz), "\r\n"), nil
+		}
+	}
+
+	passphrase, err := utils.PromptPassword(prompt)
+	if err != nil {
+		return "", errors.Wrapf(err, "provide passphrase via --%s instead", FlagPassphraseFile)
+	}
+
+	if confirm {
+		confirmation, err := utils.PromptPassword("Repeat passphrase")
+		if err != nil {
+			return "", err
+		}
+		if confirmation != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
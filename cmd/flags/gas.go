@@ -11,12 +11,41 @@ import (
 )
 
 const (
-	FlagGasLimit  = "gas"
-	FlagGasPrices = "gas-prices"
+	FlagGasLimit      = "gas"
+	FlagGasPrices     = "gas-prices"
+	FlagGasAdjustment = "gas-adjustment"
+)
+
+const (
+	// GasLimitAuto is the value of flag --gas indicates the gas limit should be estimated
+	GasLimitAuto = "auto"
+
+	FlagGasAdjustmentDesc = "multiplier applied to the estimated gas limit when --gas is auto"
 )
 
 func ReadFlagGasLimit(cmd *cobra.Command, flag string, _default uint64) (uint64, error) {
-	gasLimit, _ := cmd.Flags().GetString(flag)
+	gasLimit := readFlagStringOrNetworkProfile(cmd, flag, "gas limit", func(profile NetworkProfile) string {
+		return profile.GasLimit
+	})
+	return parseGasLimit(gasLimit, _default)
+}
+
+// ReadFlagGasLimitOrAuto reads the gas limit like ReadFlagGasLimit,
+// but returns auto=true instead when the value is GasLimitAuto.
+func ReadFlagGasLimitOrAuto(cmd *cobra.Command, flag string, _default uint64) (gasLimit uint64, auto bool, err error) {
+	value := readFlagStringOrNetworkProfile(cmd, flag, "gas limit", func(profile NetworkProfile) string {
+		return profile.GasLimit
+	})
+	if strings.EqualFold(strings.TrimSpace(value), GasLimitAuto) {
+		auto = true
+		return
+	}
+
+	gasLimit, err = parseGasLimit(value, _default)
+	return
+}
+
+func parseGasLimit(gasLimit string, _default uint64) (uint64, error) {
 	if gasLimit == "" {
 		gasLimit = fmt.Sprintf("%d", _default)
 	}
@@ -41,8 +70,22 @@ func ReadFlagGasLimit(cmd *cobra.Command, flag string, _default uint64) (uint64,
 	return num, nil
 }
 
+// ReadFlagGasAdjustment reads the gas adjustment, must be at least 1.
+func ReadFlagGasAdjustment(cmd *cobra.Command) (float64, error) {
+	gasAdjustment, err := cmd.Flags().GetFloat64(FlagGasAdjustment)
+	if err != nil {
+		return 0, err
+	}
+	if gasAdjustment < 1 {
+		return 0, fmt.Errorf("gas adjustment must be at least 1, got %f", gasAdjustment)
+	}
+	return gasAdjustment, nil
+}
+
 func ReadFlagGasPrices(cmd *cobra.Command, flag string, _default uint64) (*big.Int, error) {
-	gasPrices, _ := cmd.Flags().GetString(flag)
+	gasPrices := readFlagStringOrNetworkProfile(cmd, flag, "gas prices", func(profile NetworkProfile) string {
+		return profile.GasPrices
+	})
 	if gasPrices == "" {
 		gasPrices = fmt.Sprintf("%d", _default)
 	}
@@ -74,3 +117,21 @@ func ReadFlagGasPrices(cmd *cobra.Command, flag string, _default uint64) (*big.I
 
 	return bi, nil
 }
+
+// readFlagStringOrNetworkProfile reads the flag value,
+// if the flag is not provided, the value from network profile (if any) will be used instead of the flag default value.
+func readFlagStringOrNetworkProfile(cmd *cobra.Command, flag, name string, fromProfile func(NetworkProfile) string) string {
+	value, _ := cmd.Flags().GetString(flag)
+	if cmd.Flags().Changed(flag) {
+		return value
+	}
+
+	if profile := GetNetworkProfile(cmd); profile != nil {
+		if valueFromProfile := fromProfile(*profile); valueFromProfile != "" {
+			utils.PrintlnStdErr("INF: using", name, valueFromProfile, fmt.Sprintf("(from profile %s)", profile.Name))
+			return valueFromProfile
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,81 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	cosmoshd "github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagHdPath          = "hd-path"
+	FlagCoinType        = "coin-type"
+	FlagAccountIndex    = "account-index"
+	FlagBip39Passphrase = "bip39-passphrase"
+)
+
+const (
+	// DefaultCoinType is the SLIP-44 coin type of Ethereum
+	DefaultCoinType = 60
+
+	FlagHdPathDesc          = "HD derivation path used to derive the key from mnemonic, eg: m/44'/118'/0'/0/0, can not be used together with --" + FlagCoinType + " and --" + FlagAccountIndex
+	FlagCoinTypeDesc        = "SLIP-44 coin type used to derive the key from mnemonic, eg: 60 for Ethereum, 118 for Cosmos"
+	FlagAccountIndexDesc    = "address index used to derive the key from mnemonic, the last component of the HD path"
+	FlagBip39PassphraseDesc = "BIP39 passphrase (the 25th word) used to derive the key from mnemonic"
+)
+
+// AddHdDerivationFlags registers flags used by ReadHdDerivationFlags.
+func AddHdDerivationFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagHdPath, "", FlagHdPathDesc)
+	cmd.Flags().Uint32(FlagCoinType, DefaultCoinType, FlagCoinTypeDesc)
+	cmd.Flags().Uint32(FlagAccountIndex, 0, FlagAccountIndexDesc)
+	cmd.Flags().String(FlagBip39Passphrase, "", FlagBip39PassphraseDesc)
+}
+
+// ReadHdDerivationFlags reads the HD path and BIP39 passphrase used to derive the key from mnemonic.
+// The HD path is built from --coin-type and --account-index unless --hd-path is provided.
+func ReadHdDerivationFlags(cmd *cobra.Command) (hdPath accounts.DerivationPath, bip39Passphrase string, err error) {
+	bip39Passphrase, _ = cmd.Flags().GetString(FlagBip39Passphrase)
+
+	hdPathStr, _ := cmd.Flags().GetString(FlagHdPath)
+	hdPathStr = strings.TrimSpace(hdPathStr)
+	if hdPathStr != "" {
+		if cmd.Flags().Changed(FlagCoinType) || cmd.Flags().Changed(FlagAccountIndex) {
+			err = fmt.Errorf("--%s can not be used together with --%s or --%s", FlagHdPath, FlagCoinType, FlagAccountIndex)
+			return
+		}
+	} else {
+		coinType, _ := cmd.Flags().GetUint32(FlagCoinType)
+		accountIndex, _ := cmd.Flags().GetUint32(FlagAccountIndex)
+		hdPathStr = cosmoshd.CreateHDPath(coinType, 0, accountIndex).String()
+	}
+
+	hdPath, err = accounts.ParseDerivationPath(hdPathStr)
+	if err != nil {
+		err = errors.Wrapf(err, "invalid HD path %s", hdPathStr)
+		return
+	}
+
+	return
+}
+
+// IsHdDerivationFlagsChanged returns true if any of the HD derivation flags is provided.
+func IsHdDerivationFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range []string{FlagHdPath, FlagCoinType, FlagAccountIndex, FlagBip39Passphrase} {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MustReadHdDerivationFlags is the same as ReadHdDerivationFlags but exits on error.
+func MustReadHdDerivationFlags(cmd *cobra.Command) (accounts.DerivationPath, string) {
+	hdPath, bip39Passphrase, err := ReadHdDerivationFlags(cmd)
+	utils.ExitOnErr(err, "failed to read HD derivation flags")
+	return hdPath, bip39Passphrase
+}
@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHdDerivationFlags(t *testing.T) {
+	tests := []struct {
+		name                string
+		args                []string
+		wantHdPath          string
+		wantBip39Passphrase string
+		wantErr             bool
+	}{
+		{
+			name:       "default",
+			args:       nil,
+			wantHdPath: "m/44'/60'/0'/0/0",
+		},
+		{
+			name:       "coin type and account index",
+			args:       []string{"--coin-type", "118", "--account-index", "2"},
+			wantHdPath: "m/44'/118'/0'/0/2",
+		},
+		{
+			name:                "HD path and BIP39 passphrase",
+			args:                []string{"--hd-path", "m/44'/60'/1'/0/3", "--bip39-passphrase", "secret"},
+			wantHdPath:          "m/44'/60'/1'/0/3",
+			wantBip39Passphrase: "secret",
+		},
+		{
+			name:    "HD path can not be used together with coin type",
+			args:    []string{"--hd-path", "m/44'/60'/0'/0/0", "--coin-type", "118"},
+			wantErr: true,
+		},
+		{
+			name:    "HD path can not be used together with account index",
+			args:    []string{"--hd-path", "m/44'/60'/0'/0/0", "--account-index", "1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid HD path",
+			args:    []string{"--hd-path", "m/44'/x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			AddHdDerivationFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			hdPath, bip39Passphrase, err := ReadHdDerivationFlags(cmd)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHdPath, hdPath.String())
+			require.Equal(t, tt.wantBip39Passphrase, bip39Passphrase)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagNetwork = "network"
+)
+
+const (
+	FlagNetworkDesc = "Name of the network profile in config file to be used, profiles can be managed via 'config' commands"
+)
+
+// NetworkProfile represents the network profile in use, with its name.
+type NetworkProfile struct {
+	types.NetworkProfile
+	Name string
+
+	// Explicit is true when the profile is selected by flag --network,
+	// otherwise it is the profile selected by 'config use' command.
+	Explicit bool
+}
+
+// GetNetworkProfile returns the network profile selected by flag --network,
+// or the profile selected by 'config use' command if the flag is not provided.
+// Returns nil if no profile is selected.
+func GetNetworkProfile(cmd *cobra.Command) *NetworkProfile {
+	var networkFromFlag string
+	if cmd.Flags().Lookup(FlagNetwork) != nil {
+		networkFromFlag, _ = cmd.Flags().GetString(FlagNetwork)
+	}
+
+	configFile, err := types.GetConfigFilePath()
+	if err != nil {
+		if networkFromFlag != "" {
+			utils.ExitOnErr(err, "failed to get config file path")
+		}
+		return nil
+	}
+
+	config, err := types.LoadConfig(configFile)
+	if err != nil {
+		if networkFromFlag != "" {
+			utils.ExitOnErr(err, "failed to load config")
+		}
+		utils.PrintlnStdErr("WARN: failed to load config, network profiles are ignored:", err)
+		return nil
+	}
+
+	if networkFromFlag != "" {
+		profile, found := config.GetNetworkProfile(networkFromFlag)
+		if !found {
+			utils.PrintfStdErr("ERR: network profile [%s] does not exist in config file %s\n", networkFromFlag, configFile)
+			os.Exit(1)
+		}
+		return &NetworkProfile{
+			NetworkProfile: profile,
+			Name:           networkFromFlag,
+			Explicit:       true,
+		}
+	}
+
+	if config.Network == "" {
+		return nil
+	}
+
+	profile, found := config.GetNetworkProfile(config.Network)
+	if !found {
+		utils.PrintfStdErr("WARN: selected network profile [%s] does not exist in config file %s\n", config.Network, configFile)
+		return nil
+	}
+
+	return &NetworkProfile{
+		NetworkProfile: profile,
+		Name:           config.Network,
+		Explicit:       false,
+	}
+}
+
+// resolveFlagValue resolves value by priority:
+//  1. Flag.
+//  2. Network profile selected by flag --network.
+//  3. Environment variable.
+//  4. Network profile selected by 'config use' command.
+//  5. Default value.
+func resolveFlagValue(cmd *cobra.Command, flag, env, _default string, fromProfile func(types.NetworkProfile) string) (value, inputSource string) {
+	if cmd.Flags().Lookup(flag) != nil {
+		if valueFromFlag, _ := cmd.Flags().GetString(flag); len(strings.TrimSpace(valueFromFlag)) > 0 {
+			return valueFromFlag, "flag"
+		}
+	}
+
+	profile := GetNetworkProfile(cmd)
+
+	if profile != nil && profile.Explicit {
+		if valueFromProfile := fromProfile(profile.NetworkProfile); len(valueFromProfile) > 0 {
+			return valueFromProfile, "profile " + profile.Name
+		}
+	}
+
+	if env != "" {
+		if valueFromEnv := os.Getenv(env); len(valueFromEnv) > 0 {
+			return valueFromEnv, "environment variable"
+		}
+	}
+
+	if profile != nil && !profile.Explicit {
+		if valueFromProfile := fromProfile(profile.NetworkProfile); len(valueFromProfile) > 0 {
+			return valueFromProfile, "profile " + profile.Name
+		}
+	}
+
+	return _default, "default"
+}
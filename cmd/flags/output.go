@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagOutput = "output"
+)
+
+const (
+	FlagOutputDesc = "Output format: table (human-readable), json or yaml"
+)
+
+// ReadFlagOutputFormat reads the output format from flag --output, exit if the format is not supported.
+func ReadFlagOutputFormat(cmd *cobra.Command) utils.OutputFormat {
+	var output string
+	if cmd.Flags().Lookup(FlagOutput) != nil {
+		output, _ = cmd.Flags().GetString(FlagOutput)
+	}
+
+	format, err := utils.ParseOutputFormat(output)
+	utils.ExitOnErr(err, "failed to read flag --"+FlagOutput)
+
+	return format
+}
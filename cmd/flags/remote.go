@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/bcdevtools/devd/v3/cmd/types"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/bcdevtools/devd/v3/constants"
 	httpclient "github.com/cometbft/cometbft/rpc/client/http"
@@ -22,25 +23,24 @@ const (
 )
 
 const (
-	FlagEvmRpcDesc     = "EVM Json-RPC endpoint, default is " + constants.DEFAULT_EVM_RPC + ", can be set via environment variable " + constants.ENV_EVM_RPC
-	FlagTmRpcDesc      = "Tendermint RPC endpoint, default is " + constants.DEFAULT_TM_RPC + ", can be set via environment variable " + constants.ENV_TM_RPC
-	FlagCosmosRestDesc = "Cosmos Rest API endpoint, default is " + constants.DEFAULT_COSMOS_REST + ", can be set via environment variable " + constants.ENV_COSMOS_REST
+	FlagEvmRpcDesc     = "EVM Json-RPC endpoint, default is " + constants.DEFAULT_EVM_RPC + ", can be set via environment variable " + constants.ENV_EVM_RPC + " or network profile"
+	FlagTmRpcDesc      = "Tendermint RPC endpoint, default is " + constants.DEFAULT_TM_RPC + ", can be set via environment variable " + constants.ENV_TM_RPC + " or network profile"
+	FlagCosmosRestDesc = "Cosmos Rest API endpoint, default is " + constants.DEFAULT_COSMOS_REST + ", can be set via environment variable " + constants.ENV_COSMOS_REST + " or network profile"
 )
 
+// ReadEvmRpc returns the EVM Json-RPC endpoint from flag, environment variable, network profile or default,
+// without checking the connection.
+func ReadEvmRpc(cmd *cobra.Command) (evmRpc, inputSource string) {
+	return resolveFlagValue(cmd, FlagEvmRpc, constants.ENV_EVM_RPC, constants.DEFAULT_EVM_RPC, func(profile types.NetworkProfile) string {
+		return profile.EvmRpc
+	})
+}
+
 func MustGetEthClient(cmd *cobra.Command) (ethClient8545 *ethclient.Client, evmRpc string) {
 	var inputSource string
 	var err error
 
-	if evmRpcFromFlagRpc, _ := cmd.Flags().GetString(FlagEvmRpc); len(evmRpcFromFlagRpc) > 0 {
-		evmRpc = evmRpcFromFlagRpc
-		inputSource = "flag"
-	} else if evmRpcFromEnv := os.Getenv(constants.ENV_EVM_RPC); len(evmRpcFromEnv) > 0 {
-		evmRpc = evmRpcFromEnv
-		inputSource = "environment variable"
-	} else {
-		evmRpc = constants.DEFAULT_EVM_RPC
-		inputSource = "default"
-	}
+	evmRpc, inputSource = ReadEvmRpc(cmd)
 
 	utils.PrintlnStdErr("INF: Connecting to EVM Json-RPC", evmRpc, fmt.Sprintf("(from %s)", inputSource))
 
@@ -49,30 +49,30 @@ func MustGetEthClient(cmd *cobra.Command) (ethClient8545 *ethclient.Client, evmR
 
 	// pre-flight check to ensure the connection is working
 	_, err = ethClient8545.BlockNumber(context.Background())
-	if err != nil && strings.Contains(err.Error(), "connection refused") {
+	if utils.IsEndpointUnreachable(err) {
 		utils.PrintlnStdErr("ERR: failed to connect to EVM Json-RPC, please check the connection and try again.")
-		utils.PrintfStdErr("ERR: if you are using a custom EVM Json-RPC, please provide it via flag '--%s <your_custom>' or setting environment variable 'export %s=<your_custom>'.\n", FlagEvmRpc, constants.ENV_EVM_RPC)
+		utils.PrintfStdErr("ERR: if you are using a custom EVM Json-RPC, please provide it via flag '--%s <your_custom>', setting environment variable 'export %s=<your_custom>' or network profile '--%s <name>'.\n", FlagEvmRpc, constants.ENV_EVM_RPC, FlagNetwork)
 		os.Exit(1)
 	}
 
 	return
 }
 
+// ReadTmRpc returns the Tendermint RPC endpoint from flag, environment variable, network profile or default,
+// without checking the connection.
+func ReadTmRpc(cmd *cobra.Command) (tmRpc, inputSource string) {
+	tmRpc, inputSource = resolveFlagValue(cmd, FlagTendermintRpc, constants.ENV_TM_RPC, constants.DEFAULT_TM_RPC, func(profile types.NetworkProfile) string {
+		return profile.TmRpc
+	})
+
+	tmRpc = strings.TrimSuffix(tmRpc, "/")
+	return
+}
+
 func MustGetTmRpc(cmd *cobra.Command) (tendermintRpcHttpClient *httpclient.HTTP, tmRpc string) {
 	var inputSource string
 
-	if tmRpcFromFlag, _ := cmd.Flags().GetString(FlagTendermintRpc); len(tmRpcFromFlag) > 0 {
-		tmRpc = tmRpcFromFlag
-		inputSource = "flag"
-	} else if restFromEnv := os.Getenv(constants.ENV_TM_RPC); len(restFromEnv) > 0 {
-		tmRpc = restFromEnv
-		inputSource = "environment variable"
-	} else {
-		tmRpc = constants.DEFAULT_TM_RPC
-		inputSource = "default"
-	}
-
-	tmRpc = strings.TrimSuffix(tmRpc, "/")
+	tmRpc, inputSource = ReadTmRpc(cmd)
 	utils.PrintlnStdErr("INF: Connecting to Tendermint RPC", tmRpc, fmt.Sprintf("(from %s)", inputSource))
 
 	httpClient26657, err := jsonrpcclient.DefaultHTTPClient(tmRpc)
@@ -83,36 +83,36 @@ func MustGetTmRpc(cmd *cobra.Command) (tendermintRpcHttpClient *httpclient.HTTP,
 	if err != nil {
 		utils.PrintlnStdErr("ERR:", err)
 		utils.PrintlnStdErr("ERR: failed to connect to TM RPC, please check the connection and try again.")
-		utils.PrintfStdErr("ERR: if you are using a custom TM RPC endpoint, please provide it via flag '--%s <your_custom>' or setting environment variable 'export %s=<your_custom>'.\n", FlagTendermintRpc, constants.ENV_TM_RPC)
+		utils.PrintfStdErr("ERR: if you are using a custom TM RPC endpoint, please provide it via flag '--%s <your_custom>', setting environment variable 'export %s=<your_custom>' or network profile '--%s <name>'.\n", FlagTendermintRpc, constants.ENV_TM_RPC, FlagNetwork)
 		os.Exit(1)
 	}
 
 	return
 }
 
+// ReadCosmosRest returns the Cosmos Rest API endpoint from flag, environment variable, network profile or default,
+// without checking the connection.
+func ReadCosmosRest(cmd *cobra.Command) (rest, inputSource string) {
+	rest, inputSource = resolveFlagValue(cmd, FlagCosmosRest, constants.ENV_COSMOS_REST, constants.DEFAULT_COSMOS_REST, func(profile types.NetworkProfile) string {
+		return profile.CosmosRest
+	})
+
+	rest = strings.TrimSuffix(rest, "/")
+	return
+}
+
 func MustGetCosmosRest(cmd *cobra.Command) (rest string) {
 	var inputSource string
 
-	if restFromFlagRest, _ := cmd.Flags().GetString(FlagCosmosRest); len(restFromFlagRest) > 0 {
-		rest = restFromFlagRest
-		inputSource = "flag"
-	} else if restFromEnv := os.Getenv(constants.ENV_COSMOS_REST); len(restFromEnv) > 0 {
-		rest = restFromEnv
-		inputSource = "environment variable"
-	} else {
-		rest = constants.DEFAULT_COSMOS_REST
-		inputSource = "default"
-	}
-
-	rest = strings.TrimSuffix(rest, "/")
+	rest, inputSource = ReadCosmosRest(cmd)
 
 	utils.PrintlnStdErr("INF: Connecting to Cosmos Rest-API", rest, fmt.Sprintf("(from %s)", inputSource))
 
 	// pre-flight check to ensure the connection is working
 	_, err := http.Get(rest)
-	if err != nil && strings.Contains(err.Error(), "connection refused") {
+	if utils.IsEndpointUnreachable(err) {
 		utils.PrintlnStdErr("ERR: failed to connect to Rest API, please check the connection and try again.")
-		utils.PrintfStdErr("ERR: if you are using a custom Rest API endpoint, please provide it via flag '--%s <your_custom>' or setting environment variable 'export %s=<your_custom>'.\n", FlagCosmosRest, constants.ENV_COSMOS_REST)
+		utils.PrintfStdErr("ERR: if you are using a custom Rest API endpoint, please provide it via flag '--%s <your_custom>', setting environment variable 'export %s=<your_custom>' or network profile '--%s <name>'.\n", FlagCosmosRest, constants.ENV_COSMOS_REST, FlagNetwork)
 		os.Exit(1)
 	}
 
@@ -2,7 +2,6 @@ package hash
 
 import (
 	"encoding/hex"
-	"fmt"
 	"strings"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
@@ -23,7 +22,7 @@ Support pipe.`,
 
 			input := strings.Join(args, " ")
 			hash := crypto.Keccak256([]byte(input))
-			fmt.Println(hex.EncodeToString(hash))
+			printHashOutput(cmd, input, hex.EncodeToString(hash))
 		},
 	}
 
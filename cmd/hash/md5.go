@@ -3,7 +3,6 @@ package hash
 import (
 	"crypto/md5"
 	"encoding/hex"
-	"fmt"
 	"strings"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
@@ -23,7 +22,7 @@ Support pipe.`,
 
 			input := strings.Join(args, " ")
 			hash := md5.Sum([]byte(input))
-			fmt.Println(hex.EncodeToString(hash[:]))
+			printHashOutput(cmd, input, hex.EncodeToString(hash[:]))
 		},
 	}
 
@@ -1,6 +1,10 @@
 package hash
 
 import (
+	"fmt"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -19,3 +23,13 @@ func Commands() *cobra.Command {
 
 	return cmd
 }
+
+// printHashOutput prints the hash as is, or together with the input in JSON/YAML format.
+func printHashOutput(cmd *cobra.Command, input, hash string) {
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]string{
+		"input": input,
+		"hash":  hash,
+	}, func() {
+		fmt.Println(hash)
+	})
+}
@@ -0,0 +1,134 @@
+package keys
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagCount     = "count"
+	flagBech32Hrp = "bech32-hrp"
+)
+
+type derivedKey struct {
+	Index   uint32 `json:"index"`
+	HdPath  string `json:"hdPath"`
+	Address string `json:"address"`
+	Bech32  string `json:"bech32,omitempty"`
+}
+
+func GetKeysDeriveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "derive [?mnemonic]",
+		Short: "List addresses derived from the mnemonic, for consecutive address indexes",
+		Long: `List addresses derived from the mnemonic, for consecutive address indexes starting from the HD path provided by HD derivation flags.
+The mnemonic will be prompted (not echoed) if not provided.
+Bech32 address is shown when HRP is provided via flag --bech32-hrp or network profile,
+it is the bech32 of the EVM address for coin type 60, otherwise the Cosmos secp256k1 address.`,
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var mnemonic string
+			if len(args) > 0 {
+				mnemonic = strings.Join(args, " ")
+			} else {
+				var err error
+				mnemonic, err = utils.PromptPassword("Enter mnemonic")
+				utils.ExitOnErr(err, "failed to read mnemonic")
+			}
+			mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+
+			count, _ := cmd.Flags().GetUint32(flagCount)
+			if count < 1 {
+				utils.PrintfStdErr("ERR: --%s must be positive\n", flagCount)
+				os.Exit(1)
+			}
+
+			baseHdPath, bip39Passphrase := flags.MustReadHdDerivationFlags(cmd)
+			if len(baseHdPath) < 1 {
+				utils.PrintlnStdErr("ERR: HD path must not be empty")
+				os.Exit(1)
+			}
+
+			bech32Hrp := mustReadBech32HrpOrEmpty(cmd)
+			useEvmAddressForBech32 := len(baseHdPath) > 1 && baseHdPath[1] == accounts.DefaultRootDerivationPath[1]
+
+			derivedKeys := make([]derivedKey, 0, count)
+			for i := uint32(0); i < count; i++ {
+				hdPath := make(accounts.DerivationPath, len(baseHdPath))
+				copy(hdPath, baseHdPath)
+
+				lastIndex := hdPath[len(hdPath)-1]
+				if lastIndex+i < lastIndex || (lastIndex+i)&0x80000000 != lastIndex&0x80000000 {
+					utils.PrintlnStdErr("ERR: address index overflow at", i)
+					os.Exit(1)
+				}
+				hdPath[len(hdPath)-1] = lastIndex + i
+
+				privateKey, err := utils.FromMnemonicToPrivateKeyWithHdPath(mnemonic, bip39Passphrase, hdPath)
+				utils.ExitOnErr(err, "failed to derive private key from mnemonic")
+
+				derived := derivedKey{
+					Index:   (lastIndex + i) &^ 0x80000000,
+					HdPath:  hdPath.String(),
+					Address: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+				}
+
+				if bech32Hrp != "" {
+					var addressBytes []byte
+					if useEvmAddressForBech32 {
+						addressBytes = crypto.PubkeyToAddress(privateKey.PublicKey).Bytes()
+					} else {
+						pubKey := &secp256k1.PubKey{Key: crypto.CompressPubkey(&privateKey.PublicKey)}
+						addressBytes = pubKey.Address().Bytes()
+					}
+
+					derived.Bech32, err = bech32.ConvertAndEncode(bech32Hrp, addressBytes)
+					utils.ExitOnErr(err, "failed to convert address to bech32 address")
+				}
+
+				derivedKeys = append(derivedKeys, derived)
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), derivedKeys, func() {
+				printRow := func(colIndex, colHdPath, colAddress, colBech32 string) {
+					if bech32Hrp == "" {
+						fmt.Printf("%-5s | %-24s | %-42s\n", colIndex, colHdPath, colAddress)
+					} else {
+						fmt.Printf("%-5s | %-24s | %-42s | %s\n", colIndex, colHdPath, colAddress, colBech32)
+					}
+				}
+
+				printRow("Index", "HD Path", "Address", "Bech32")
+				for _, derived := range derivedKeys {
+					printRow(fmt.Sprint(derived.Index), derived.HdPath, derived.Address, derived.Bech32)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().Uint32(flagCount, 5, "number of addresses to derive")
+	cmd.Flags().String(flagBech32Hrp, "", "Bech32 HRP of account address, eg: ethm, default is the Bech32 HRP of the network profile")
+	flags.AddHdDerivationFlags(cmd)
+
+	return cmd
+}
+
+// mustReadBech32HrpOrEmpty reads the Bech32 HRP from flag, or from the network profile in use.
+func mustReadBech32HrpOrEmpty(cmd *cobra.Command) string {
+	bech32Hrp, _ := cmd.Flags().GetString(flagBech32Hrp)
+	if bech32Hrp == "" {
+		if profile := flags.GetNetworkProfile(cmd); profile != nil {
+			bech32Hrp = profile.Bech32Hrp
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSpace(strings.ToLower(bech32Hrp)), "1")
+}
@@ -0,0 +1,252 @@
+package keys
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagKeystore = "keystore"
+	flagYes      = "yes"
+)
+
+func GetKeysAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [name]",
+		Short: "Generate a new key from a new mnemonic, then store it encrypted with a passphrase",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			keyStore := mustGetKeyStoreForNewKey(name)
+
+			entropy, err := bip39.NewEntropy(256)
+			utils.ExitOnErr(err, "failed to generate entropy")
+
+			mnemonic, err := bip39.NewMnemonic(entropy)
+			utils.ExitOnErr(err, "failed to generate mnemonic")
+
+			hdPath, bip39Passphrase := flags.MustReadHdDerivationFlags(cmd)
+
+			privateKey, err := utils.FromMnemonicToPrivateKeyWithHdPath(mnemonic, bip39Passphrase, hdPath)
+			utils.ExitOnErr(err, "failed to derive private key from mnemonic")
+
+			passphrase, err := flags.ReadPassphrase(cmd, fmt.Sprintf("Enter passphrase to encrypt key [%s]", name), true)
+			utils.ExitOnErr(err, "failed to read passphrase")
+
+			keyInfo, err := keyStore.Add(name, privateKey, passphrase)
+			utils.ExitOnErr(err, "failed to add key")
+
+			utils.PrintlnStdErr("WARN: Write down the mnemonic below and keep it safe, it is the only way to recover the key if the keystore is lost:")
+			utils.PrintlnStdErr(mnemonic)
+			utils.PrintlnStdErr("INF: HD path:", hdPath.String())
+			if bip39Passphrase != "" {
+				utils.PrintlnStdErr("WARN: The BIP39 passphrase is also required to recover the key")
+			}
+			utils.PrintlnStdErr("INF: Key", name, "saved")
+
+			printKeyInfo(cmd, keyInfo)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagPassphraseFile, "", flags.FlagPassphraseFileDesc)
+	flags.AddHdDerivationFlags(cmd)
+
+	return cmd
+}
+
+func GetKeysImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [name] [?keystore JSON file]",
+		Short: "Import a private key, mnemonic or Web3 Secret Storage JSON file as a named key",
+		Long: `Import a private key, mnemonic or Web3 Secret Storage JSON file as a named key.
+If the keystore JSON file is provided, it will be stored as is after verifying the passphrase.
+Otherwise, the private key or mnemonic will be prompted (not echoed) then encrypted with a new passphrase.
+Mnemonic is derived using the HD derivation flags, default is m/44'/60'/0'/0/0.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			keyStore := mustGetKeyStoreForNewKey(name)
+
+			var keyInfo types.KeyInfo
+			if len(args) > 1 {
+				keyJson, err := os.ReadFile(args[1])
+				utils.ExitOnErr(err, "failed to read keystore JSON file")
+
+				passphrase, err := flags.ReadPassphrase(cmd, "Enter passphrase of the keystore JSON file", false)
+				utils.ExitOnErr(err, "failed to read passphrase")
+
+				keyInfo, err = keyStore.Import(name, keyJson, passphrase)
+				utils.ExitOnErr(err, "failed to import key")
+			} else {
+				secret, err := utils.PromptPassword("Enter private key or mnemonic")
+				utils.ExitOnErr(err, "failed to read secret")
+
+				hdPath, bip39Passphrase := flags.MustReadHdDerivationFlags(cmd)
+
+				privateKey, err := utils.FromSecretToPrivateKey(secret, bip39Passphrase, hdPath)
+				utils.ExitOnErr(err, "failed to read secret key")
+
+				passphrase, err := flags.ReadPassphrase(cmd, fmt.Sprintf("Enter passphrase to encrypt key [%s]", name), true)
+				utils.ExitOnErr(err, "failed to read passphrase")
+
+				keyInfo, err = keyStore.Add(name, privateKey, passphrase)
+				utils.ExitOnErr(err, "failed to import key")
+			}
+
+			utils.PrintlnStdErr("INF: Key", name, "saved")
+
+			printKeyInfo(cmd, keyInfo)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagPassphraseFile, "", flags.FlagPassphraseFileDesc)
+	flags.AddHdDerivationFlags(cmd)
+
+	return cmd
+}
+
+func GetKeysListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List stored keys",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			keyStore := flags.MustGetKeyStore()
+
+			keys, err := keyStore.List()
+			utils.ExitOnErr(err, "failed to list keys")
+
+			if len(keys) == 0 {
+				utils.PrintlnStdErr("INF: no key found")
+				return
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), keys, func() {
+				printRow := func(colName, colAddress string) {
+					fmt.Printf("%-24s | %-42s\n", colName, colAddress)
+				}
+
+				printRow("Name", "Address")
+				for _, key := range keys {
+					printRow(key.Name, key.Address.Hex())
+				}
+			})
+		},
+	}
+
+	return cmd
+}
+
+func GetKeysShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show address of the stored key",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			keyStore := flags.MustGetKeyStore()
+
+			keyInfo, err := keyStore.Get(args[0])
+			utils.ExitOnErr(err, "failed to get key")
+
+			printKeyInfo(cmd, keyInfo)
+		},
+	}
+
+	return cmd
+}
+
+func GetKeysExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [name]",
+		Short: "Export the private key of the stored key, or the encrypted keystore JSON",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			keyStore := flags.MustGetKeyStore()
+
+			if cmd.Flags().Changed(flagKeystore) {
+				keyJson, err := keyStore.Export(name)
+				utils.ExitOnErr(err, "failed to export key")
+
+				fmt.Println(string(keyJson))
+				return
+			}
+
+			if !keyStore.Has(name) {
+				utils.PrintfStdErr("ERR: key [%s] does not exist\n", name)
+				os.Exit(1)
+			}
+
+			passphrase, err := flags.ReadPassphrase(cmd, fmt.Sprintf("Enter passphrase of key [%s]", name), false)
+			utils.ExitOnErr(err, "failed to read passphrase")
+
+			privateKey, err := keyStore.Decrypt(name, passphrase)
+			utils.ExitOnErr(err, "failed to decrypt key")
+
+			utils.PrintlnStdErr("WARN: Never share the private key, anyone has it can take full control of the account")
+			fmt.Printf("0x%x\n", crypto.FromECDSA(privateKey))
+		},
+	}
+
+	cmd.Flags().Bool(flagKeystore, false, "export the encrypted Web3 Secret Storage JSON instead of the private key")
+	cmd.Flags().String(flags.FlagPassphraseFile, "", flags.FlagPassphraseFileDesc)
+
+	return cmd
+}
+
+func GetKeysDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete [name]",
+		Aliases: []string{"rm"},
+		Short:   "Delete the stored key",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			keyStore := flags.MustGetKeyStore()
+
+			keyInfo, err := keyStore.Get(name)
+			utils.ExitOnErr(err, "failed to get key")
+
+			if !cmd.Flags().Changed(flagYes) {
+				utils.PrintfStdErr("ERR: deleting key [%s] of %s is irreversible, export it first if needed, then confirm by providing flag '--%s'\n", name, keyInfo.Address.Hex(), flagYes)
+				os.Exit(1)
+			}
+
+			err = keyStore.Delete(name)
+			utils.ExitOnErr(err, "failed to delete key")
+
+			utils.PrintlnStdErr("INF: Key", name, "of", keyInfo.Address.Hex(), "deleted")
+		},
+	}
+
+	cmd.Flags().Bool(flagYes, false, "confirm deleting the key")
+
+	return cmd
+}
+
+func mustGetKeyStoreForNewKey(name string) *types.KeyStore {
+	utils.ExitOnErr(types.ValidateKeyName(name), "invalid key name")
+
+	keyStore := flags.MustGetKeyStore()
+	if keyStore.Has(name) {
+		utils.PrintfStdErr("ERR: key [%s] already exists\n", name)
+		os.Exit(1)
+	}
+
+	return keyStore
+}
+
+func printKeyInfo(cmd *cobra.Command, keyInfo types.KeyInfo) {
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), keyInfo, func() {
+		fmt.Println("Name:", keyInfo.Name)
+		fmt.Println("Address:", keyInfo.Address.Hex())
+	})
+}
@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Commands registers a sub-tree of commands
+func Commands() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage named keys, stored encrypted in Web3 Secret Storage format under ~/.devd/keys",
+	}
+
+	cmd.AddCommand(
+		GetKeysAddCommand(),
+		GetKeysImportCommand(),
+		GetKeysListCommand(),
+		GetKeysShowCommand(),
+		GetKeysExportCommand(),
+		GetKeysDeleteCommand(),
+		GetKeysDeriveCommand(),
+	)
+
+	return cmd
+}
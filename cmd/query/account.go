@@ -8,8 +8,11 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"regexp"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 
@@ -34,21 +37,7 @@ func GetQueryAccountCommand() *cobra.Command {
 
 			restApiEndpoint := flags.MustGetCosmosRest(cmd)
 
-			utils.PrintlnStdErr("INF: querying bech32 prefix")
-			bech32Prefix, statusCode, err := fetchBech32PrefixFromRest(restApiEndpoint)
-			if err != nil {
-				if statusCode == 501 {
-					utils.PrintlnStdErr("ERR: REST API does not support query bech32 prefix info")
-				} else {
-					utils.PrintlnStdErr("ERR: failed to fetch bech32 prefix:", err)
-				}
-				os.Exit(1)
-			}
-			bech32, err := sdk.Bech32ifyAddressBytes(bech32Prefix, evmAddrs[0].Bytes())
-			if err == nil && bech32 == "" {
-				err = errors.New("output bech32 address is empty")
-			}
-			utils.ExitOnErr(err, "failed to convert address to bech32")
+			bech32 := mustGetBech32AddressFromRest(cmd, restApiEndpoint, evmAddrs[0])
 			utils.PrintlnStdErr("INF: querying account", bech32)
 			response, statusCode, err := fetchAccountDetailsFromRest(restApiEndpoint, bech32)
 			if err != nil {
@@ -103,8 +92,7 @@ func GetQueryAccountCommand() *cobra.Command {
 				}
 			}
 
-			bz, err := json.Marshal(accountInfoAsMap)
-			utils.TryPrintBeautyJson(bz)
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), accountInfoAsMap, nil)
 		},
 	}
 
@@ -113,6 +101,51 @@ func GetQueryAccountCommand() *cobra.Command {
 	return cmd
 }
 
+// mustGetBech32AddressFromRest converts the address into bech32 format,
+// using the bech32 prefix of the network profile or the one queried from Rest API.
+func mustGetBech32AddressFromRest(cmd *cobra.Command, restApiEndpoint string, addr common.Address) string {
+	var bech32Prefix string
+	if profile := flags.GetNetworkProfile(cmd); profile != nil && profile.Bech32Hrp != "" {
+		bech32Prefix = profile.Bech32Hrp
+		utils.PrintlnStdErr("INF: using bech32 prefix", bech32Prefix, fmt.Sprintf("(from profile %s)", profile.Name))
+	} else {
+		utils.PrintlnStdErr("INF: querying bech32 prefix")
+		var statusCode int
+		var err error
+		bech32Prefix, statusCode, err = fetchBech32PrefixFromRest(restApiEndpoint)
+		if err != nil {
+			if statusCode == 501 {
+				utils.PrintlnStdErr("ERR: REST API does not support query bech32 prefix info")
+			} else {
+				utils.PrintlnStdErr("ERR: failed to fetch bech32 prefix:", err)
+			}
+			os.Exit(1)
+		}
+	}
+	bech32, err := sdk.Bech32ifyAddressBytes(bech32Prefix, addr.Bytes())
+	if err == nil && bech32 == "" {
+		err = errors.New("output bech32 address is empty")
+	}
+	utils.ExitOnErr(err, "failed to convert address to bech32")
+	return bech32
+}
+
+// mustGetBech32AddressFromInput returns the bech32 address of the input,
+// 0x address is converted using mustGetBech32AddressFromRest, bech32 address is returned as is.
+func mustGetBech32AddressFromInput(cmd *cobra.Command, restApiEndpoint string, input string) string {
+	evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(input)
+	if err != nil {
+		utils.PrintlnStdErr("ERR:", err)
+		os.Exit(1)
+	}
+
+	if regexp.MustCompile(`^(0x)?[a-fA-F\d]{40}$`).MatchString(input) {
+		return mustGetBech32AddressFromRest(cmd, restApiEndpoint, evmAddrs[0])
+	}
+
+	return strings.ToLower(input)
+}
+
 func fetchAccountDetailsFromRest(rest, bech32Address string) (response string, statusCode int, err error) {
 	var resp *http.Response
 	resp, err = http.Get(rest + "/cosmos/auth/v1beta1/accounts/" + bech32Address)
@@ -7,21 +7,22 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"os"
 	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/bcdevtools/devd/v3/cmd/flags"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 )
 
 const (
 	flagErc20 = "erc20"
+	flagBank  = "bank"
 )
 
 func GetQueryBalanceCommand() *cobra.Command {
@@ -30,7 +31,8 @@ func GetQueryBalanceCommand() *cobra.Command {
 		Aliases: []string{"b"},
 		Short:   "Get native balance of account. Optionally query ERC-20 token balances.",
 		Long: fmt.Sprintf(`Get native balance of account. Optionally query ERC-20 token balances of if 2nd arg is provided or flag --%s is used.
-Bech32 account address is accepted.`, flagErc20),
+Use flag --%s to query balances of all denoms in x/bank module, denoms paired with x/erc20 or VFBC contract are linked to the contract.
+Bech32 account address is accepted.`, flagErc20, flagBank),
 		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args...)
@@ -39,7 +41,7 @@ Bech32 account address is accepted.`, flagErc20),
 				return
 			}
 
-			ethClient8545, _ := flags.MustGetEthClient(cmd)
+			ethClient8545, evmRpc := flags.MustGetEthClient(cmd)
 
 			// construct contract list to query
 
@@ -61,13 +63,21 @@ Bech32 account address is accepted.`, flagErc20),
 				})
 			}
 
-			// if flag --erc20 is used, query x/erc20 module and virtual frontier bank contracts
+			// if flag --erc20 or --bank is used, query x/erc20 module and virtual frontier bank contracts
 			// (order them by denom to ensure balance of higher priority are fetched/displayed first)
 
-			if cmd.Flags().Changed(flagErc20) {
-				restApiEndpoint := flags.MustGetCosmosRest(cmd)
+			queryErc20 := cmd.Flags().Changed(flagErc20)
+			queryBank := cmd.Flags().Changed(flagBank)
 
-				existingErc20TokenPairs, statusCode, err := fetchErc20ModuleTokenPairsFromRest(restApiEndpoint)
+			var restApiEndpoint string
+			var existingErc20TokenPairs []Erc20ModuleTokenPair
+			var existingVfbcPairs []VfbcTokenPair
+
+			if queryErc20 || queryBank {
+				restApiEndpoint = flags.MustGetCosmosRest(cmd)
+
+				var statusCode int
+				existingErc20TokenPairs, statusCode, err = fetchErc20ModuleTokenPairsFromRest(restApiEndpoint)
 				if err != nil {
 					if statusCode == 501 {
 						utils.PrintlnStdErr("WARN: `x/erc20` module is not available on the chain")
@@ -76,32 +86,11 @@ Bech32 account address is accepted.`, flagErc20),
 					}
 				} else {
 					slices.SortFunc(existingErc20TokenPairs, func(l, r Erc20ModuleTokenPair) int {
-						ln := utils.OrderNumberForDenom(l.Denom)
-						rn := utils.OrderNumberForDenom(r.Denom)
-						if ln < rn {
-							return -1
-						} else if ln > rn {
-							return 1
-						} else {
-							return 0
-						}
+						return compareDenom(l.Denom, r.Denom)
 					})
-
-					for _, erc20TokenPair := range existingErc20TokenPairs {
-						if !erc20TokenPair.Enabled {
-							continue
-						}
-
-						erc20Contracts = append(erc20Contracts, contract{
-							contractAddr:    common.HexToAddress(erc20TokenPair.Erc20Address),
-							source:          "x/erc20",
-							skipZeroBalance: true,
-							extra:           erc20TokenPair.Denom,
-						})
-					}
 				}
 
-				existingVfbcPairs, statusCode, err := fetchVirtualFrontierBankContractPairsFromRest(restApiEndpoint)
+				existingVfbcPairs, statusCode, err = fetchVirtualFrontierBankContractPairsFromRest(restApiEndpoint)
 				if err != nil {
 					if statusCode == 501 {
 						utils.PrintlnStdErr("WARN: virtual frontier contract feature is not available on the chain")
@@ -110,29 +99,36 @@ Bech32 account address is accepted.`, flagErc20),
 					}
 				} else {
 					slices.SortFunc(existingVfbcPairs, func(l, r VfbcTokenPair) int {
-						ln := utils.OrderNumberForDenom(l.MinDenom)
-						rn := utils.OrderNumberForDenom(r.MinDenom)
-						if ln < rn {
-							return -1
-						} else if ln > rn {
-							return 1
-						} else {
-							return 0
-						}
+						return compareDenom(l.MinDenom, r.MinDenom)
+					})
+				}
+			}
+
+			if queryErc20 {
+				for _, erc20TokenPair := range existingErc20TokenPairs {
+					if !erc20TokenPair.Enabled {
+						continue
+					}
+
+					erc20Contracts = append(erc20Contracts, contract{
+						contractAddr:    common.HexToAddress(erc20TokenPair.Erc20Address),
+						source:          "x/erc20",
+						skipZeroBalance: true,
+						extra:           erc20TokenPair.Denom,
 					})
+				}
 
-					for _, vfbcPair := range existingVfbcPairs {
-						if !vfbcPair.Enabled {
-							continue
-						}
-
-						erc20Contracts = append(erc20Contracts, contract{
-							contractAddr:    common.HexToAddress(vfbcPair.ContractAddress),
-							source:          "vfbc",
-							skipZeroBalance: true,
-							extra:           vfbcPair.MinDenom,
-						})
+				for _, vfbcPair := range existingVfbcPairs {
+					if !vfbcPair.Enabled {
+						continue
 					}
+
+					erc20Contracts = append(erc20Contracts, contract{
+						contractAddr:    common.HexToAddress(vfbcPair.ContractAddress),
+						source:          "vfbc",
+						skipZeroBalance: true,
+						extra:           vfbcPair.MinDenom,
+					})
 				}
 			}
 
@@ -144,11 +140,9 @@ Bech32 account address is accepted.`, flagErc20),
 			accountAddr := evmAddrs[0]
 			utils.PrintlnStdErr("INF: Account", accountAddr)
 
-			printRow := func(colType, colContract, colSymbol, colBalance, colRaw, colDecimals, extra string) {
-				fmt.Printf("%-7s | %42s | %-10s | %28s | %27s | %8s | %-1s\n", colType, colContract, colSymbol, colBalance, colRaw, colDecimals, extra)
-			}
+			outputFormat := flags.ReadFlagOutputFormat(cmd)
 
-			printRow("Type", "Contract", "Symbol", "Balance", "Raw", "Decimals", "Extra")
+			var rows []balanceRow
 
 			nativeBalance, err := ethClient8545.BalanceAt(context.Background(), accountAddr, contextHeight)
 			utils.ExitOnErr(err, "failed to get account balance")
@@ -156,20 +150,76 @@ Bech32 account address is accepted.`, flagErc20),
 			display, _, _, err := utils.ConvertNumberIntoDisplayWithExponent(nativeBalance, 18)
 			utils.ExitOnErr(err, "failed to convert number into display with exponent")
 
-			printRow("native", "-", "(native)", display, nativeBalance.String(), "18", "")
+			rows = append(rows, balanceRow{
+				Type:     "native",
+				Contract: "",
+				Symbol:   "(native)",
+				Balance:  display,
+				Raw:      nativeBalance.String(),
+				Decimals: 18,
+				Extra:    "",
+			})
+
+			if queryBank {
+				rows = append(rows, mustGetBankBalanceRows(cmd, restApiEndpoint, args[0], accountAddr, contextHeight, existingErc20TokenPairs, existingVfbcPairs)...)
+			}
 
-			for _, erc20Contract := range erc20Contracts {
-				tokenBalance, tokenBalanceDisplay, contractSymbol, contractDecimals, _, _, err := fetchBalanceForErc20Contract(erc20Contract.contractAddr, contextHeight, ethClient8545, accountAddr, erc20Contract.source)
-				if err != nil {
-					continue
+			if len(erc20Contracts) > 0 {
+				contractAddrs := make([]common.Address, len(erc20Contracts))
+				for i, erc20Contract := range erc20Contracts {
+					contractAddrs[i] = erc20Contract.contractAddr
 				}
 
-				if tokenBalance.Sign() == 0 && erc20Contract.skipZeroBalance {
-					continue
+				contractsInfo := fetchErc20ContractsInfoInBatch(ethClient8545, evmRpc, contractAddrs, &accountAddr, false, contextHeight)
+
+				for _, erc20Contract := range erc20Contracts {
+					tokenBalance, tokenBalanceDisplay, contractSymbol, contractDecimals, err := getBalanceForErc20Contract(contractsInfo[erc20Contract.contractAddr], erc20Contract.contractAddr, accountAddr, erc20Contract.source)
+					if err != nil {
+						continue
+					}
+
+					if tokenBalance.Sign() == 0 && erc20Contract.skipZeroBalance {
+						continue
+					}
+
+					rows = append(rows, balanceRow{
+						Type:     erc20Contract.source,
+						Contract: erc20Contract.contractAddr.String(),
+						Symbol:   contractSymbol,
+						Balance:  tokenBalanceDisplay,
+						Raw:      tokenBalance.String(),
+						Decimals: contractDecimals.Int64(),
+						Extra:    erc20Contract.extra,
+					})
 				}
+			}
 
-				printRow(erc20Contract.source, erc20Contract.contractAddr.String(), contractSymbol, tokenBalanceDisplay, tokenBalance.String(), contractDecimals.String(), erc20Contract.extra)
+			if restApiEndpoint != "" {
+				resolveIbcDenomOfBalanceRows(restApiEndpoint, rows)
 			}
+
+			utils.PrintOutput(outputFormat, balanceOutput{
+				Account:  accountAddr.String(),
+				Balances: rows,
+			}, func() {
+				printRow := func(colType, colContract, colSymbol, colBalance, colRaw, colDecimals, extra string) {
+					fmt.Printf("%-7s | %42s | %-10s | %28s | %27s | %8s | %-1s\n", colType, colContract, colSymbol, colBalance, colRaw, colDecimals, extra)
+				}
+
+				printRow("Type", "Contract", "Symbol", "Balance", "Raw", "Decimals", "Extra")
+
+				for _, row := range rows {
+					contract := row.Contract
+					if contract == "" {
+						contract = "-"
+					}
+					extra := row.Extra
+					if row.IbcTrace != "" {
+						extra = fmt.Sprintf("%s (%s)", extra, row.IbcTrace)
+					}
+					printRow(row.Type, contract, row.Symbol, row.Balance, row.Raw, fmt.Sprintf("%d", row.Decimals), extra)
+				}
+			})
 		},
 	}
 
@@ -177,53 +227,72 @@ Bech32 account address is accepted.`, flagErc20),
 	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
 	cmd.Flags().String(flags.FlagHeight, "", "query balance at specific height")
 	cmd.Flags().Bool(flagErc20, false, "query balance of ERC-20 contracts of `x/erc20` module and virtual frontier bank contracts")
+	cmd.Flags().Bool(flagBank, false, "query balances of all denoms in x/bank module of the account")
 
 	return cmd
 }
 
-func fetchBalanceForErc20Contract(contractAddr common.Address, contextHeight *big.Int, ethClient8545 *ethclient.Client, accountAddr common.Address, sourceInputContract string) (
+type balanceOutput struct {
+	Account  string       `json:"account"`
+	Balances []balanceRow `json:"balances"`
+}
+
+type balanceRow struct {
+	Type     string `json:"type"`
+	Contract string `json:"contract,omitempty"`
+	Symbol   string `json:"symbol"`
+	Balance  string `json:"balance"`
+	Raw      string `json:"raw"`
+	Decimals int64  `json:"decimals"`
+	Extra    string `json:"extra,omitempty"`
+	IbcTrace string `json:"ibcTrace,omitempty"`
+}
+
+// resolveIbcDenomOfBalanceRows fills the IBC denom trace of rows those denom is an IBC voucher,
+// bank balance without denom metadata will use the base denom as symbol.
+func resolveIbcDenomOfBalanceRows(restApiEndpoint string, rows []balanceRow) {
+	resolver := newIbcDenomResolver(restApiEndpoint)
+	defer resolver.save()
+
+	for i, row := range rows {
+		trace, ok := resolver.resolve(row.Extra)
+		if !ok {
+			continue
+		}
+
+		rows[i].IbcTrace = trace.FullPath()
+		if row.Type == "bank" && row.Symbol == row.Extra {
+			rows[i].Symbol = trace.BaseDenom
+		}
+	}
+}
+
+// getBalanceForErc20Contract reads the balance and metadata from the batch query result of the contract,
+// errors are printed to stderr.
+func getBalanceForErc20Contract(info *erc20ContractInfo, contractAddr, accountAddr common.Address, sourceInputContract string) (
 	tokenBalance *big.Int, tokenBalanceDisplay, contractSymbol string,
-	contractDecimals, balancePartHigh, balancePartLow *big.Int,
+	contractDecimals *big.Int,
 	err error,
 ) {
-	bz, err := ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &contractAddr,
-		Data: []byte{0x95, 0xd8, 0x9b, 0x41}, // symbol()
-	}, contextHeight)
-	if err != nil {
+	if err = info.errSymbol; err != nil {
 		utils.PrintlnStdErr("ERR: failed to get symbol for", sourceInputContract, "contract", contractAddr, ":", err)
 		return
 	}
+	contractSymbol = *info.symbol
 
-	contractSymbol, err = utils.AbiDecodeString(bz)
-	if err != nil {
-		utils.PrintlnStdErr("ERR: failed to decode symbol for", sourceInputContract, "contract", contractAddr, ":", err)
-		return
-	}
-
-	bz, err = ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &contractAddr,
-		Data: []byte{0x31, 0x3c, 0xe5, 0x67}, // decimals()
-	}, contextHeight)
-	if err != nil {
+	if err = info.errDecimals; err != nil {
 		utils.PrintlnStdErr("ERR: failed to get decimals for", sourceInputContract, "contract", contractAddr, ":", err)
 		return
 	}
+	contractDecimals = info.decimals
 
-	contractDecimals = new(big.Int).SetBytes(bz)
-
-	bz, err = ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &contractAddr,
-		Data: append([]byte{0x70, 0xa0, 0x82, 0x31}, common.BytesToHash(accountAddr.Bytes()).Bytes()...), // balanceOf(address)
-	}, contextHeight)
-	if err != nil {
+	if err = info.errBalance; err != nil {
 		utils.PrintlnStdErr("ERR: failed to get", sourceInputContract, "contract token", contractAddr, "balance for", accountAddr, ":", err)
 		return
 	}
+	tokenBalance = info.balance
 
-	tokenBalance = new(big.Int).SetBytes(bz)
-
-	tokenBalanceDisplay, balancePartHigh, balancePartLow, err = utils.ConvertNumberIntoDisplayWithExponent(tokenBalance, int(contractDecimals.Int64()))
+	tokenBalanceDisplay, _, _, err = utils.ConvertNumberIntoDisplayWithExponent(tokenBalance, int(contractDecimals.Int64()))
 	if err != nil {
 		utils.PrintlnStdErr("ERR: failed to convert number", tokenBalance.String(), "decimals", contractDecimals.String(), "into display with exponent for", sourceInputContract, "contract token balance:", err)
 		return
@@ -232,6 +301,94 @@ func fetchBalanceForErc20Contract(contractAddr common.Address, contextHeight *bi
 	return
 }
 
+// mustGetBankBalanceRows queries x/bank balances of the account then converts into balance rows,
+// using exponent from denom metadata, ordered by denom. Balances are queried at the context height, latest if nil.
+func mustGetBankBalanceRows(
+	cmd *cobra.Command, restApiEndpoint, inputAddress string, accountAddr common.Address, contextHeight *big.Int,
+	erc20TokenPairs []Erc20ModuleTokenPair, vfbcPairs []VfbcTokenPair,
+) []balanceRow {
+	bech32 := strings.ToLower(inputAddress)
+	if common.IsHexAddress(inputAddress) {
+		bech32 = mustGetBech32AddressFromRest(cmd, restApiEndpoint, accountAddr)
+	}
+	utils.PrintlnStdErr("INF: querying bank balances of", bech32)
+
+	bankBalances, statusCode, err := fetchBankBalancesFromRest(restApiEndpoint, bech32, contextHeight)
+	if err != nil {
+		if statusCode == 501 {
+			utils.PrintlnStdErr("ERR: REST API does not support query bank balances")
+		} else {
+			utils.PrintlnStdErr("ERR: failed to fetch bank balances:", err)
+		}
+		os.Exit(1)
+	}
+
+	denomsMetadata, _, err := fetchDenomsMetadataFromRest(restApiEndpoint)
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to fetch denoms metadata, balances are shown as raw:", err)
+	}
+
+	pairedContracts := make(map[string]string)
+	for _, vfbcPair := range vfbcPairs {
+		pairedContracts[vfbcPair.MinDenom] = common.HexToAddress(vfbcPair.ContractAddress).String()
+	}
+	for _, erc20TokenPair := range erc20TokenPairs {
+		pairedContracts[erc20TokenPair.Denom] = common.HexToAddress(erc20TokenPair.Erc20Address).String()
+	}
+
+	slices.SortStableFunc(bankBalances, func(l, r BankBalance) int {
+		return compareDenom(l.Denom, r.Denom)
+	})
+
+	rows := make([]balanceRow, 0, len(bankBalances))
+	for _, bankBalance := range bankBalances {
+		amount, ok := new(big.Int).SetString(bankBalance.Amount, 10)
+		if !ok {
+			utils.PrintlnStdErr("ERR: failed to parse balance", bankBalance.Amount, "of denom", bankBalance.Denom)
+			continue
+		}
+
+		symbol := bankBalance.Denom
+		var exponent int
+		if metadata, found := denomsMetadata[bankBalance.Denom]; found {
+			symbol = metadata.DisplaySymbol()
+			exponent = metadata.DisplayExponent()
+		}
+
+		display, _, _, err := utils.ConvertNumberIntoDisplayWithExponent(amount, exponent)
+		if err != nil {
+			utils.PrintlnStdErr("WARN: failed to convert balance of denom", bankBalance.Denom, "into display with exponent", exponent, ":", err)
+			display = amount.String()
+			exponent = 0
+		}
+
+		rows = append(rows, balanceRow{
+			Type:     "bank",
+			Contract: pairedContracts[bankBalance.Denom],
+			Symbol:   symbol,
+			Balance:  display,
+			Raw:      amount.String(),
+			Decimals: int64(exponent),
+			Extra:    bankBalance.Denom,
+		})
+	}
+
+	return rows
+}
+
+// compareDenom orders denom by utils.OrderNumberForDenom, then by name.
+func compareDenom(l, r string) int {
+	ln := utils.OrderNumberForDenom(l)
+	rn := utils.OrderNumberForDenom(r)
+	if ln < rn {
+		return -1
+	} else if ln > rn {
+		return 1
+	} else {
+		return strings.Compare(l, r)
+	}
+}
+
 type Erc20ModuleTokenPair struct {
 	Erc20Address string `json:"erc20_address"`
 	Denom        string `json:"denom"`
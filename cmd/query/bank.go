@@ -0,0 +1,66 @@
+package query
+
+import (
+	"math/big"
+)
+
+type BankBalance struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+func fetchBankBalancesFromRest(rest, bech32Address string, height *big.Int) (balances []BankBalance, statusCode int, err error) {
+	var response struct {
+		Balances []BankBalance `json:"balances"`
+	}
+	statusCode, err = fetchJsonFromRestAtHeight(rest, "/cosmos/bank/v1beta1/balances/"+bech32Address+"?pagination.limit=10000", "bank balances", height, &response)
+	balances = response.Balances
+	return
+}
+
+type DenomUnit struct {
+	Denom    string `json:"denom"`
+	Exponent int    `json:"exponent"`
+}
+
+type DenomMetadata struct {
+	Base       string      `json:"base"`
+	Display    string      `json:"display"`
+	Symbol     string      `json:"symbol"`
+	DenomUnits []DenomUnit `json:"denom_units"`
+}
+
+// DisplayExponent returns the exponent of the display denom unit, 0 if not found.
+func (m DenomMetadata) DisplayExponent() int {
+	for _, unit := range m.DenomUnits {
+		if unit.Denom == m.Display {
+			return unit.Exponent
+		}
+	}
+	return 0
+}
+
+// DisplaySymbol returns the symbol, or the display denom if symbol is not set.
+func (m DenomMetadata) DisplaySymbol() string {
+	if m.Symbol != "" {
+		return m.Symbol
+	}
+	return m.Display
+}
+
+// fetchDenomsMetadataFromRest returns metadata of all denoms, indexed by the base denom.
+func fetchDenomsMetadataFromRest(rest string) (denomsMetadata map[string]DenomMetadata, statusCode int, err error) {
+	var response struct {
+		Metadatas []DenomMetadata `json:"metadatas"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/bank/v1beta1/denoms_metadata?pagination.limit=10000", "denoms metadata", &response)
+	if err != nil {
+		return
+	}
+
+	denomsMetadata = make(map[string]DenomMetadata, len(response.Metadatas))
+	for _, metadata := range response.Metadatas {
+		denomsMetadata[metadata.Base] = metadata
+	}
+	return
+}
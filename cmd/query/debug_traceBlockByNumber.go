@@ -0,0 +1,186 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const traceBlockTimeout = 60 * time.Second
+
+func GetQueryEvmRpcDebugTraceBlockByNumberCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "debug_traceBlockByNumber [height dec or 0xHex or latest]",
+		Aliases: []string{"evm-trace-block"},
+		Short:   "Query 'debug_traceBlockByNumber' from EVM RPC",
+		Long: `Query 'debug_traceBlockByNumber' from EVM RPC, trace all txs in the block, with optional tracer name.
+Require 'debug' namespace enabled in EVM RPC node.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ethClient, evmRpc := flags.MustGetEthClient(cmd)
+
+			callTreeOptions := mustReadCallTreeOptionsOrNil(cmd)
+
+			var blockNumber *big.Int
+			if input := strings.ToLower(strings.TrimSpace(args[0])); input != "latest" {
+				var err error
+				blockNumber, err = utils.ReadShortIntOrHex(input)
+				if err != nil || blockNumber.Sign() < 0 {
+					utils.PrintlnStdErr("ERR: invalid block number:", args[0])
+					os.Exit(1)
+				}
+				if blockNumber.Sign() == 0 {
+					utils.PrintlnStdErr("ERR: genesis block is not traceable")
+					os.Exit(1)
+				}
+			}
+			if blockNumber == nil {
+				// resolve the latest block number, so the tx hashes are fetched from the same block
+				latest, err := ethClient.BlockNumber(context.Background())
+				utils.ExitOnErr(err, "failed to get latest block number")
+				blockNumber = new(big.Int).SetUint64(latest)
+			}
+
+			params := []types.JsonRpcQueryParam{
+				types.NewJsonRpcBlockTagQueryParam(blockNumber),
+			}
+
+			if tracerConfig := mustReadTracerConfigOrNil(cmd); tracerConfig != nil {
+				paramTracerConfig, err := types.NewJsonRpcQueryParam(tracerConfig)
+				utils.ExitOnErr(err, "failed to create json rpc query param")
+				params = append(params, paramTracerConfig)
+			}
+
+			bz, err := types.DoEvmRpcQuery(
+				evmRpc,
+				types.NewJsonRpcQueryBuilder(
+					"debug_traceBlockByNumber",
+					params...,
+				),
+				traceBlockTimeout,
+			)
+			utils.ExitOnErr(err, "failed to trace block")
+
+			res, err := types.ParseJsonRpcResponse[[]map[string]interface{}](bz)
+			utils.ExitOnErr(err, "failed to parse trace block response")
+			txTraces := *(res.(*[]map[string]interface{}))
+
+			injectTxHashesIntoTxTraces(evmRpc, blockNumber, txTraces)
+
+			for _, txTrace := range txTraces {
+				if traceResult, ok := txTrace["result"].(map[string]interface{}); ok {
+					recursivelyTranslateTraceFrames(traceResult)
+				}
+			}
+
+			if callTreeOptions != nil {
+				printTxTracesAsCallTrees(txTraces, *callTreeOptions)
+			} else {
+				bz, err = json.Marshal(txTraces)
+				utils.ExitOnErr(err, "failed to marshal response trace block")
+
+				utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
+			}
+
+			if !cmd.Flag(flagNoTranslate).Changed {
+				for i, txTrace := range txTraces {
+					if traceResult, ok := txTrace["result"].(map[string]interface{}); ok {
+						printTraceRevertReason(traceResult, fmt.Sprintf("EVM execution of tx #%d %v", i, txTrace["txHash"]))
+					}
+				}
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().String(flagTracer, "callTracer", "EVM tracer")
+	cmd.Flags().Bool(flagNoTranslate, false, "do not translate and print EVM revert error message")
+	addCallTreeFlags(cmd)
+
+	return cmd
+}
+
+// injectTxHashesIntoTxTraces injects the tx hash into each trace of the block, in order of txs in the block,
+// because nodes prior to geth v1.11 (including Ethermint) do not include the tx hash in the trace result.
+func injectTxHashesIntoTxTraces(evmRpc string, blockNumber *big.Int, txTraces []map[string]interface{}) {
+	var missingTxHash bool
+	for _, txTrace := range txTraces {
+		if _, found := txTrace["txHash"]; !found {
+			missingTxHash = true
+			break
+		}
+	}
+	if !missingTxHash {
+		return
+	}
+
+	bz, err := types.DoEvmRpcQuery(
+		evmRpc,
+		types.NewJsonRpcQueryBuilder(
+			"eth_getBlockByNumber",
+			types.NewJsonRpcBlockTagQueryParam(blockNumber),
+			types.NewJsonRpcBoolQueryParam(false),
+		),
+		0,
+	)
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to fetch block to get tx hashes:", err)
+		return
+	}
+
+	blockInfoAsMap, err := getResultObjectFromEvmRpcResponse(bz)
+	if err != nil || blockInfoAsMap == nil {
+		utils.PrintlnStdErr("WARN: failed to get tx hashes of the block:", err)
+		return
+	}
+
+	txHashes, _ := blockInfoAsMap["transactions"].([]interface{})
+	if len(txHashes) != len(txTraces) {
+		utils.PrintlnStdErr("WARN: number of txs in the block", len(txHashes), "does not match number of traces", len(txTraces))
+		return
+	}
+
+	for i, txTrace := range txTraces {
+		if _, found := txTrace["txHash"]; !found {
+			txTrace["txHash"] = txHashes[i]
+		}
+	}
+}
+
+// printTxTracesAsCallTrees prints call tree of each tx in the block,
+// txs without any failed call are skipped when only failed calls are requested.
+func printTxTracesAsCallTrees(txTraces []map[string]interface{}, opts utils.CallTreeOptions) {
+	var printed int
+	for i, txTrace := range txTraces {
+		traceResult, ok := txTrace["result"].(map[string]interface{})
+		if !ok {
+			fmt.Printf("Tx #%d %v: %v\n", i, txTrace["txHash"], txTrace["error"])
+			printed++
+			continue
+		}
+
+		if opts.OnlyFailed && !utils.HasFailedCallFrame(traceResult) {
+			continue
+		}
+
+		if printed > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Tx #%d %v:\n", i, txTrace["txHash"])
+		fmt.Print(utils.RenderCallTree(traceResult, opts))
+		printed++
+	}
+
+	if printed == 0 {
+		utils.PrintlnStdErr("INF: no tx to render")
+	}
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+func GetQueryEvmRpcDebugTraceCallCommand() *cobra.Command {
+	const flagFrom = "from"
+	const flagValue = "value"
+
+	cmd := &cobra.Command{
+		Use:     "debug_traceCall [contract] [call data]",
+		Aliases: []string{"evm-trace-call"},
+		Short:   "Query 'debug_traceCall' from EVM RPC",
+		Long: `Query 'debug_traceCall' from EVM RPC, trace a hypothetical call without sending a transaction, with optional tracer name.
+Require 'debug' namespace enabled in EVM RPC node.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			_, evmRpc := flags.MustGetEthClient(cmd)
+
+			callTreeOptions := mustReadCallTreeOptionsOrNil(cmd)
+
+			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args[0])
+			utils.ExitOnErr(err, "failed to parse contract address")
+
+			callData, err := hexutil.Decode(strings.TrimSpace(args[1]))
+			utils.ExitOnErr(err, "failed to decode call data, must be 0x-prefixed hex")
+
+			callArgs := map[string]interface{}{
+				"to":   evmAddrs[0],
+				"data": hexutil.Bytes(callData),
+			}
+
+			if from, _ := cmd.Flags().GetString(flagFrom); from != "" {
+				fromAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(from)
+				utils.ExitOnErr(err, fmt.Sprintf("failed to parse address of --%s", flagFrom))
+				callArgs["from"] = fromAddrs[0]
+			}
+
+			value, err := flags.ReadFlagShortIntOrHexOrNil(cmd, flagValue)
+			utils.ExitOnErr(err, "failed to parse value")
+			if value != nil && value.Sign() == 1 {
+				utils.PrintfStdErr("INF: using value: %s\n", value)
+				callArgs["value"] = (*hexutil.Big)(value)
+			} else if value != nil && value.Sign() < 0 {
+				utils.PrintlnStdErr("ERR: value must not be negative")
+				os.Exit(1)
+			}
+
+			height, err := flags.ReadFlagBlockNumberOrNil(cmd, flags.FlagHeight)
+			utils.ExitOnErr(err, "failed to parse block number")
+			if height != nil && height.Sign() == 1 {
+				utils.PrintfStdErr("INF: using block number: %s\n", height.String())
+			}
+
+			paramCallArgs, err := types.NewJsonRpcQueryParam(callArgs)
+			utils.ExitOnErr(err, "failed to create json rpc query param")
+
+			params := []types.JsonRpcQueryParam{
+				paramCallArgs,
+				types.NewJsonRpcBlockTagQueryParam(height),
+			}
+
+			if tracerConfig := mustReadTracerConfigOrNil(cmd); tracerConfig != nil {
+				paramTracerConfig, err := types.NewJsonRpcQueryParam(tracerConfig)
+				utils.ExitOnErr(err, "failed to create json rpc query param")
+				params = append(params, paramTracerConfig)
+			}
+
+			bz, err := types.DoEvmRpcQuery(
+				evmRpc,
+				types.NewJsonRpcQueryBuilder(
+					"debug_traceCall",
+					params...,
+				),
+				0,
+			)
+			utils.ExitOnErr(err, "failed to trace call")
+
+			traceContentAsMap, err := getResultObjectFromEvmRpcResponse(bz)
+			utils.ExitOnErr(err, "failed to get result object from response")
+
+			recursivelyTranslateTraceFrames(traceContentAsMap)
+
+			if callTreeOptions != nil {
+				fmt.Print(utils.RenderCallTree(traceContentAsMap, *callTreeOptions))
+			} else {
+				bz, err = json.Marshal(traceContentAsMap)
+				utils.ExitOnErr(err, "failed to marshal response trace call")
+
+				utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
+			}
+
+			if !cmd.Flag(flagNoTranslate).Changed {
+				printTraceRevertReason(traceContentAsMap, "EVM execution")
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().StringP(flagFrom, "f", "", "the address from which the call is sent, support 0x or bech32")
+	cmd.Flags().StringP(flagValue, "v", "", "value sent with this call, support short int and hex")
+	cmd.Flags().StringP(flags.FlagHeight, "h", "latest", "the context height of the block to exec, accept \"latest\"/short int/hex")
+	cmd.Flags().String(flagTracer, "callTracer", "EVM tracer")
+	cmd.Flags().Bool(flagNoTranslate, false, "do not translate and print EVM revert error message")
+	addCallTreeFlags(cmd)
+
+	return cmd
+}
@@ -1,7 +1,6 @@
 package query
 
 import (
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,13 +11,17 @@ import (
 
 	"github.com/bcdevtools/devd/v3/cmd/types"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
-	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/cobra"
 )
 
 const (
 	flagTracer      = "tracer"
 	flagNoTranslate = "no-translate"
+	flagTree        = "tree"
+	flagMaxDepth    = "max-depth"
+	flagOnlyFailed  = "only-failed"
+	flagSig         = "sig"
 )
 
 func GetQueryEvmRpcDebugTraceTransactionCommand() *cobra.Command {
@@ -39,18 +42,18 @@ Require 'debug' namespace enabled in EVM RPC node.`,
 				os.Exit(1)
 			}
 
+			callTreeOptions := mustReadCallTreeOptionsOrNil(cmd)
+
 			var params []types.JsonRpcQueryParam
 
 			paramTransactionHash, err := types.NewJsonRpcStringQueryParam(input)
 			utils.ExitOnErr(err, "failed to create json rpc query param")
 			params = append(params, paramTransactionHash)
 
-			if tracer := cmd.Flag(flagTracer).Value.String(); tracer != "" {
-				if !regexp.MustCompile(`^\w+$`).MatchString(tracer) {
-					utils.PrintlnStdErr("ERR: invalid tracer name:", tracer)
-					os.Exit(1)
-				}
-				params = append(params, types.NewJsonRpcRawQueryParam(fmt.Sprintf(`{"tracer":"%s"}`, tracer)))
+			if tracerConfig := mustReadTracerConfigOrNil(cmd); tracerConfig != nil {
+				paramTracerConfig, err := types.NewJsonRpcQueryParam(tracerConfig)
+				utils.ExitOnErr(err, "failed to create json rpc query param")
+				params = append(params, paramTracerConfig)
 			}
 
 			bz, err := types.DoEvmRpcQuery(
@@ -68,37 +71,17 @@ Require 'debug' namespace enabled in EVM RPC node.`,
 
 			recursivelyTranslateTraceFrames(traceContentAsMap)
 
-			bz, err = json.Marshal(traceContentAsMap)
-			utils.ExitOnErr(err, "failed to marshal response trace tx")
+			if callTreeOptions != nil {
+				fmt.Print(utils.RenderCallTree(traceContentAsMap, *callTreeOptions))
+			} else {
+				bz, err = json.Marshal(traceContentAsMap)
+				utils.ExitOnErr(err, "failed to marshal response trace tx")
 
-			utils.TryPrintBeautyJson(bz)
+				utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
+			}
 
 			if !cmd.Flag(flagNoTranslate).Changed {
-				// try to decode error message if any
-
-				type structOfError struct {
-					Error  string `json:"error"`
-					Output string `json:"output"`
-				}
-
-				res, err := types.ParseJsonRpcResponse[structOfError](bz)
-				if err == nil {
-					if resStruct, ok := res.(*structOfError); ok {
-						if resStruct.Error != "" && resStruct.Output != "" && resStruct.Error == vm.ErrExecutionReverted.Error() {
-							if regexp.MustCompile(`^0x08c379a0[a-f\d]{192,}$`).MatchString(resStruct.Output) && (len(resStruct.Output)-10 /*exclude sig of error*/)%64 == 0 {
-								bz, err := hex.DecodeString(resStruct.Output[10:])
-								errMsg, err := utils.AbiDecodeString(bz)
-								if err == nil && errMsg != "" {
-									utils.PrintfStdErr(
-										"ERR: EVM execution reverted with message [%s], this translation can be omitted by providing flag '--%s'\n",
-										errMsg,
-										flagNoTranslate,
-									)
-								}
-							}
-						}
-					}
-				}
+				printTraceRevertReason(traceContentAsMap, "EVM execution")
 			}
 		},
 	}
@@ -106,10 +89,104 @@ Require 'debug' namespace enabled in EVM RPC node.`,
 	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
 	cmd.Flags().String(flagTracer, "callTracer", "EVM tracer")
 	cmd.Flags().Bool(flagNoTranslate, false, "do not translate and print EVM revert error message")
+	addCallTreeFlags(cmd)
 
 	return cmd
 }
 
+// mustReadTracerConfigOrNil returns the tracer config of the --tracer flag, nil if not provided.
+func mustReadTracerConfigOrNil(cmd *cobra.Command) map[string]string {
+	tracer := cmd.Flag(flagTracer).Value.String()
+	if tracer == "" {
+		return nil
+	}
+
+	if !regexp.MustCompile(`^\w+$`).MatchString(tracer) {
+		utils.PrintlnStdErr("ERR: invalid tracer name:", tracer)
+		os.Exit(1)
+	}
+
+	return map[string]string{
+		"tracer": tracer,
+	}
+}
+
+// printTraceRevertReason prints the decoded revert reason of the top-level trace frame, if the execution was reverted.
+func printTraceRevertReason(frame map[string]interface{}, execution string) {
+	if errMsg, ok := utils.TryDecodeTraceFrameRevertReason(frame); ok {
+		utils.PrintfStdErr(
+			"ERR: %s reverted with message [%s], this translation can be omitted by providing flag '--%s'\n",
+			execution,
+			errMsg,
+			flagNoTranslate,
+		)
+	}
+}
+
+// addCallTreeFlags adds flags to render the callTracer output as call tree.
+func addCallTreeFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagTree, false, "render the calls as an indented tree, require callTracer")
+	cmd.Flags().Int(flagMaxDepth, 0, "maximum depth of nested calls to render in the tree, 0 means unlimited")
+	cmd.Flags().Bool(flagOnlyFailed, false, "render only the failed calls and their parents in the tree")
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc+", used to decode method selectors in the tree")
+	cmd.Flags().StringArray(flagSig, []string{}, "method signature, eg: 'transfer(address,uint256)', used to decode method selectors in the tree")
+}
+
+// mustReadCallTreeOptionsOrNil returns the options to render the call tree, nil if --tree is not provided.
+// Method selectors are decoded using the ABI file, the method signatures provided via --sig and the well-known method signatures.
+func mustReadCallTreeOptionsOrNil(cmd *cobra.Command) *utils.CallTreeOptions {
+	if tree, _ := cmd.Flags().GetBool(flagTree); !tree {
+		for _, flag := range []string{flagMaxDepth, flagOnlyFailed, flags.FlagAbi, flagSig} {
+			if cmd.Flags().Changed(flag) {
+				utils.PrintlnStdErr(fmt.Sprintf("ERR: --%s requires --%s", flag, flagTree))
+				os.Exit(1)
+			}
+		}
+		return nil
+	}
+
+	if tracer := cmd.Flag(flagTracer).Value.String(); tracer != "callTracer" {
+		utils.PrintlnStdErr(fmt.Sprintf("ERR: --%s requires callTracer, got tracer [%s]", flagTree, tracer))
+		os.Exit(1)
+	}
+
+	maxDepth, _ := cmd.Flags().GetInt(flagMaxDepth)
+	if maxDepth < 0 {
+		utils.PrintlnStdErr(fmt.Sprintf("ERR: --%s must not be negative", flagMaxDepth))
+		os.Exit(1)
+	}
+
+	onlyFailed, _ := cmd.Flags().GetBool(flagOnlyFailed)
+
+	methods := make(map[string]string)
+
+	contractAbi, err := flags.ReadFlagAbiOrNil(cmd)
+	utils.ExitOnErr(err, "failed to read ABI file")
+	if contractAbi != nil {
+		for _, method := range contractAbi.Methods {
+			methods[hexutil.Encode(method.ID)] = method.Sig
+		}
+	}
+
+	signatures, _ := cmd.Flags().GetStringArray(flagSig)
+	for _, signature := range signatures {
+		method, err := utils.ParseMethodSignature(signature)
+		utils.ExitOnErr(err, fmt.Sprintf("failed to parse method signature [%s]", signature))
+		methods[hexutil.Encode(method.ID)] = method.Sig
+	}
+
+	return &utils.CallTreeOptions{
+		MaxDepth:   maxDepth,
+		OnlyFailed: onlyFailed,
+		ResolveMethod: func(selector []byte) (string, bool) {
+			if signature, found := methods[hexutil.Encode(selector)]; found {
+				return signature, true
+			}
+			return utils.LookupWellKnownMethodSignature(selector)
+		},
+	}
+}
+
 func recursivelyTranslateTraceFrames(_map map[string]interface{}) {
 	if _map == nil {
 		return
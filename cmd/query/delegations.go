@@ -0,0 +1,158 @@
+package query
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+type delegationOutput struct {
+	Validator         string        `json:"validator"`
+	Moniker           string        `json:"moniker,omitempty"`
+	Amount            string        `json:"amount"`
+	AmountRaw         BankBalance   `json:"amountRaw"`
+	PendingRewards    string        `json:"pendingRewards"`
+	PendingRewardsRaw []BankBalance `json:"pendingRewardsRaw,omitempty"`
+}
+
+type unbondingEntryOutput struct {
+	Validator      string `json:"validator"`
+	Moniker        string `json:"moniker,omitempty"`
+	Amount         string `json:"amount"`
+	AmountRaw      string `json:"amountRaw"`
+	CreationHeight string `json:"creationHeight"`
+	CompletionTime string `json:"completionTime"`
+}
+
+type delegationsOutput struct {
+	Delegator           string                 `json:"delegator"`
+	Delegations         []delegationOutput     `json:"delegations"`
+	TotalDelegated      string                 `json:"totalDelegated"`
+	TotalPendingRewards string                 `json:"totalPendingRewards"`
+	Unbonding           []unbondingEntryOutput `json:"unbonding"`
+}
+
+func GetQueryDelegationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delegations [0xAccount/Bech32]",
+		Aliases: []string{"dels"},
+		Short:   "Get delegations of the account, with pending rewards and unbonding entries",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			input := strings.TrimSpace(args[0])
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			delegator := mustGetBech32AddressFromInput(cmd, restApiEndpoint, input)
+			utils.PrintlnStdErr("INF: querying delegations of", delegator)
+
+			delegations, statusCode, err := fetchStakingDelegationsFromRest(restApiEndpoint, delegator)
+			if err != nil {
+				if statusCode == 501 {
+					utils.PrintlnStdErr("ERR: REST API does not support query delegations")
+				} else {
+					utils.PrintlnStdErr("ERR: failed to fetch delegations:", err)
+				}
+				os.Exit(1)
+			}
+
+			rewardsByValidator := make(map[string][]BankBalance)
+			rewards, _, err := fetchDistributionDelegationRewardsFromRest(restApiEndpoint, delegator)
+			if err != nil {
+				utils.PrintlnStdErr("WARN: failed to fetch pending rewards:", err)
+			}
+			for _, reward := range rewards {
+				rewardsByValidator[reward.ValidatorAddress] = reward.Reward
+			}
+
+			unbondingDelegations, _, err := fetchStakingUnbondingDelegationsFromRest(restApiEndpoint, delegator)
+			if err != nil {
+				utils.PrintlnStdErr("WARN: failed to fetch unbonding delegations:", err)
+			}
+
+			monikers := make(map[string]string)
+			if validators, _, err := fetchStakingValidatorsFromRest(restApiEndpoint); err != nil {
+				utils.PrintlnStdErr("WARN: failed to fetch validators, monikers are not shown:", err)
+			} else {
+				for _, validator := range validators {
+					monikers[validator.OperatorAddress] = validator.Description.Moniker
+				}
+			}
+
+			formatter := newBondDenomFormatter(restApiEndpoint)
+
+			output := delegationsOutput{
+				Delegator:   delegator,
+				Delegations: make([]delegationOutput, 0, len(delegations)),
+				Unbonding:   make([]unbondingEntryOutput, 0),
+			}
+
+			var delegatedCoins, rewardCoins []BankBalance
+			for _, delegation := range delegations {
+				valoper := delegation.Delegation.ValidatorAddress
+				output.Delegations = append(output.Delegations, delegationOutput{
+					Validator:         valoper,
+					Moniker:           monikers[valoper],
+					Amount:            formatter.format(delegation.Balance.Amount, delegation.Balance.Denom),
+					AmountRaw:         delegation.Balance,
+					PendingRewards:    formatter.formatCoins(rewardsByValidator[valoper]),
+					PendingRewardsRaw: rewardsByValidator[valoper],
+				})
+				delegatedCoins = append(delegatedCoins, delegation.Balance)
+			}
+			for _, reward := range rewards {
+				rewardCoins = append(rewardCoins, reward.Reward...)
+			}
+			output.TotalDelegated = formatter.formatCoins(sumCoins(delegatedCoins))
+			output.TotalPendingRewards = formatter.formatCoins(sumCoins(rewardCoins))
+
+			for _, unbondingDelegation := range unbondingDelegations {
+				valoper := unbondingDelegation.ValidatorAddress
+				for _, entry := range unbondingDelegation.Entries {
+					output.Unbonding = append(output.Unbonding, unbondingEntryOutput{
+						Validator:      valoper,
+						Moniker:        monikers[valoper],
+						Amount:         formatter.format(entry.Balance, ""),
+						AmountRaw:      entry.Balance,
+						CreationHeight: entry.CreationHeight,
+						CompletionTime: entry.CompletionTime,
+					})
+				}
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Delegator:", output.Delegator)
+
+				fmt.Println("\nDelegations:")
+				printDelegationRow := func(colMoniker, colValidator, colAmount, colRewards string) {
+					fmt.Printf("%-20s | %-52s | %28s | %-1s\n", colMoniker, colValidator, colAmount, colRewards)
+				}
+				printDelegationRow("Moniker", "Validator", "Amount", "Pending rewards")
+				for _, delegation := range output.Delegations {
+					printDelegationRow(truncateMoniker(delegation.Moniker), delegation.Validator, delegation.Amount, delegation.PendingRewards)
+				}
+				fmt.Println("Total delegated:", output.TotalDelegated)
+				fmt.Println("Total pending rewards:", output.TotalPendingRewards)
+
+				if len(output.Unbonding) > 0 {
+					fmt.Println("\nUnbonding:")
+					printUnbondingRow := func(colMoniker, colValidator, colAmount, colHeight, colCompletion string) {
+						fmt.Printf("%-20s | %-52s | %28s | %10s | %-1s\n", colMoniker, colValidator, colAmount, colHeight, colCompletion)
+					}
+					printUnbondingRow("Moniker", "Validator", "Amount", "Height", "Completion time")
+					for _, entry := range output.Unbonding {
+						printUnbondingRow(truncateMoniker(entry.Moniker), entry.Validator, entry.Amount, entry.CreationHeight, entry.CompletionTime)
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
@@ -1,14 +1,12 @@
 package query
 
 import (
-	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/bcdevtools/devd/v3/cmd/flags"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 )
@@ -21,7 +19,7 @@ func GetQueryErc20Command() *cobra.Command {
 Support bech32 address format`,
 		Args: cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
-			ethClient8545, _ := flags.MustGetEthClient(cmd)
+			ethClient8545, evmRpc := flags.MustGetEthClient(cmd)
 
 			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args...)
 			utils.ExitOnErr(err, "failed to get evm address from input")
@@ -36,62 +34,70 @@ Support bech32 address format`,
 				accountAddr = evmAddrs[1]
 			}
 
-			bz, err := ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-				To:   &contractAddr,
-				Data: []byte{0x95, 0xd8, 0x9b, 0x41}, // symbol()
-			}, contextHeight)
-			utils.ExitOnErr(err, "failed to get contract symbol")
-
-			contractSymbol, err := utils.AbiDecodeString(bz)
-			utils.ExitOnErr(err, "failed to decode contract symbol")
-
-			bz, err = ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-				To:   &contractAddr,
-				Data: []byte{0x31, 0x3c, 0xe5, 0x67}, // decimals()
-			}, contextHeight)
-			utils.ExitOnErr(err, "failed to get contract decimals")
-
-			contractDecimals := new(big.Int).SetBytes(bz).Uint64()
-
-			var totalSupply *big.Int
-			bz, err = ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-				To:   &contractAddr,
-				Data: []byte{0x18, 0x16, 0x0d, 0xdd}, // totalSupply()
-			}, contextHeight)
-			if err == nil && len(bz) > 0 {
-				totalSupply = new(big.Int).SetBytes(bz)
-				if totalSupply.Sign() != 1 {
-					totalSupply = nil
-				}
+			var optionalAccountAddr *common.Address
+			if accountAddr != (common.Address{}) {
+				optionalAccountAddr = &accountAddr
 			}
 
-			var accountBalance *big.Int
-			if accountAddr != (common.Address{}) {
-				bz, err = ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
-					To:   &contractAddr,
-					Data: append([]byte{0x70, 0xa0, 0x82, 0x31}, common.BytesToHash(accountAddr.Bytes()).Bytes()...), // balanceOf(address)
-				}, contextHeight)
-				utils.ExitOnErr(err, "failed to get account token balance")
+			contractsInfo := fetchErc20ContractsInfoInBatch(ethClient8545, evmRpc, []common.Address{contractAddr}, optionalAccountAddr, true, contextHeight)
+			contractInfo := contractsInfo[contractAddr]
+
+			utils.ExitOnErr(contractInfo.errSymbol, "failed to get contract symbol")
+			contractSymbol := *contractInfo.symbol
 
-				accountBalance = new(big.Int).SetBytes(bz)
+			utils.ExitOnErr(contractInfo.errDecimals, "failed to get contract decimals")
+			contractDecimals := contractInfo.decimals.Uint64()
+
+			totalSupply := contractInfo.totalSupply
+			if totalSupply != nil && totalSupply.Sign() != 1 {
+				totalSupply = nil
 			}
 
-			fmt.Println("Contract Symbol:", contractSymbol)
-			fmt.Println("Contract Decimals:", contractDecimals)
+			var accountBalance *big.Int
+			if optionalAccountAddr != nil {
+				utils.ExitOnErr(contractInfo.errBalance, "failed to get account token balance")
+				accountBalance = contractInfo.balance
+			}
+
+			output := erc20Output{
+				Contract: contractAddr.String(),
+				Symbol:   contractSymbol,
+				Decimals: contractDecimals,
+			}
 			if totalSupply != nil {
 				display, _, _, err := utils.ConvertNumberIntoDisplayWithExponent(totalSupply, int(contractDecimals))
 				utils.ExitOnErr(err, "failed to convert number into display with exponent")
-				fmt.Println("Total Supply:", display, contractSymbol)
+				output.TotalSupply = &erc20AmountOutput{
+					Raw:     totalSupply.String(),
+					Display: display,
+				}
 			}
 			if accountBalance != nil {
 				display, high, low, err := utils.ConvertNumberIntoDisplayWithExponent(accountBalance, int(contractDecimals))
 				utils.ExitOnErr(err, "failed to convert number into display with exponent")
-				fmt.Println("Account token balance:")
-				fmt.Println(" - Raw:", accountBalance)
-				fmt.Println(" - Display:", display, contractSymbol)
-				fmt.Println("  + High:", high)
-				fmt.Println("  + Low:", low)
+				output.Account = accountAddr.String()
+				output.AccountBalance = &erc20AmountOutput{
+					Raw:     accountBalance.String(),
+					Display: display,
+					High:    high.String(),
+					Low:     low.String(),
+				}
 			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Contract Symbol:", output.Symbol)
+				fmt.Println("Contract Decimals:", output.Decimals)
+				if output.TotalSupply != nil {
+					fmt.Println("Total Supply:", output.TotalSupply.Display, output.Symbol)
+				}
+				if output.AccountBalance != nil {
+					fmt.Println("Account token balance:")
+					fmt.Println(" - Raw:", output.AccountBalance.Raw)
+					fmt.Println(" - Display:", output.AccountBalance.Display, output.Symbol)
+					fmt.Println("  + High:", output.AccountBalance.High)
+					fmt.Println("  + Low:", output.AccountBalance.Low)
+				}
+			})
 		},
 	}
 
@@ -100,3 +106,19 @@ Support bech32 address format`,
 
 	return cmd
 }
+
+type erc20Output struct {
+	Contract       string             `json:"contract"`
+	Symbol         string             `json:"symbol"`
+	Decimals       uint64             `json:"decimals"`
+	TotalSupply    *erc20AmountOutput `json:"totalSupply,omitempty"`
+	Account        string             `json:"account,omitempty"`
+	AccountBalance *erc20AmountOutput `json:"accountBalance,omitempty"`
+}
+
+type erc20AmountOutput struct {
+	Raw     string `json:"raw"`
+	Display string `json:"display"`
+	High    string `json:"high,omitempty"`
+	Low     string `json:"low,omitempty"`
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// maxEvmRpcBatchSize is the maximum number of requests to be sent in a single JSON-RPC batch,
+// some nodes reject batch requests that are too large.
+const maxEvmRpcBatchSize = 300
+
+var (
+	erc20SelectorSymbol      = []byte{0x95, 0xd8, 0x9b, 0x41} // symbol()
+	erc20SelectorDecimals    = []byte{0x31, 0x3c, 0xe5, 0x67} // decimals()
+	erc20SelectorTotalSupply = []byte{0x18, 0x16, 0x0d, 0xdd} // totalSupply()
+	erc20SelectorBalanceOf   = []byte{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+)
+
+// erc20ContractInfo holds result of ERC-20 queries of a contract,
+// fields are nil when not requested or failed to fetch.
+type erc20ContractInfo struct {
+	symbol      *string
+	decimals    *big.Int
+	totalSupply *big.Int
+	balance     *big.Int
+
+	errSymbol      error
+	errDecimals    error
+	errTotalSupply error
+	errBalance     error
+}
+
+// fetchErc20ContractsInfoInBatch queries symbol(), decimals(), optionally totalSupply() and balanceOf(account)
+// of the given ERC-20 contracts using JSON-RPC batch requests, to minimize number of round-trips.
+// If a batch request fails, e.g. the node does not support batch requests,
+// queries of that batch are sent one by one using `eth_call`.
+func fetchErc20ContractsInfoInBatch(ethClient8545 *ethclient.Client, evmRpc string, contracts []common.Address, account *common.Address, withTotalSupply bool, contextHeight *big.Int) map[common.Address]*erc20ContractInfo {
+	type pendingQuery struct {
+		contract common.Address
+		data     []byte
+		onReply  func(bz []byte, err error)
+	}
+
+	result := make(map[common.Address]*erc20ContractInfo, len(contracts))
+	var queries []pendingQuery
+
+	for _, contract := range contracts {
+		if _, found := result[contract]; found {
+			continue
+		}
+
+		info := &erc20ContractInfo{}
+		result[contract] = info
+
+		queries = append(queries, pendingQuery{
+			contract: contract,
+			data:     erc20SelectorSymbol,
+			onReply: func(bz []byte, err error) {
+				if err == nil {
+					var symbol string
+					symbol, err = utils.AbiDecodeString(bz)
+					if err == nil {
+						info.symbol = &symbol
+					}
+				}
+				info.errSymbol = err
+			},
+		}, pendingQuery{
+			contract: contract,
+			data:     erc20SelectorDecimals,
+			onReply: func(bz []byte, err error) {
+				if err == nil {
+					info.decimals = new(big.Int).SetBytes(bz)
+				}
+				info.errDecimals = err
+			},
+		})
+
+		if withTotalSupply {
+			queries = append(queries, pendingQuery{
+				contract: contract,
+				data:     erc20SelectorTotalSupply,
+				onReply: func(bz []byte, err error) {
+					if err == nil {
+						info.totalSupply = new(big.Int).SetBytes(bz)
+					}
+					info.errTotalSupply = err
+				},
+			})
+		}
+
+		if account != nil {
+			queries = append(queries, pendingQuery{
+				contract: contract,
+				data:     append(append([]byte{}, erc20SelectorBalanceOf...), common.BytesToHash(account.Bytes()).Bytes()...),
+				onReply: func(bz []byte, err error) {
+					if err == nil {
+						info.balance = new(big.Int).SetBytes(bz)
+					}
+					info.errBalance = err
+				},
+			})
+		}
+	}
+
+	for start := 0; start < len(queries); start += maxEvmRpcBatchSize {
+		end := start + maxEvmRpcBatchSize
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		batch := types.NewJsonRpcBatchQueryBuilder()
+		requestIds := make([]uint64, 0, end-start)
+		for _, query := range queries[start:end] {
+			qb := newEthCallQueryBuilder(query.contract, query.data, contextHeight)
+			batch.Add(qb)
+			requestIds = append(requestIds, qb.RequestId())
+		}
+
+		responses, err := types.DoEvmRpcBatchQuery(evmRpc, batch, 0)
+		if err != nil {
+			utils.PrintlnStdErr("WARN: failed to query ERC-20 contracts in batch, fallback to query one by one:", err)
+
+			for _, query := range queries[start:end] {
+				contract := query.contract
+				query.onReply(ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
+					To:   &contract,
+					Data: query.data,
+				}, contextHeight))
+			}
+			continue
+		}
+
+		for i, query := range queries[start:end] {
+			query.onReply(parseEthCallResponse(responses[requestIds[i]]))
+		}
+	}
+
+	return result
+}
+
+// newEthCallQueryBuilder builds an `eth_call` request, at latest block if context height is nil.
+func newEthCallQueryBuilder(contract common.Address, data []byte, contextHeight *big.Int) types.JsonRpcQueryBuilder {
+	return types.NewJsonRpcQueryBuilder(
+		"eth_call",
+		types.MustNewJsonRpcQueryParam(map[string]any{
+			"to":   contract,
+			"data": hexutil.Bytes(data),
+		}),
+		types.NewJsonRpcBlockTagQueryParam(contextHeight),
+	)
+}
+
+func parseEthCallResponse(bz []byte) ([]byte, error) {
+	res, err := types.ParseJsonRpcResponse[string](bz)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(*(res.(*string)))
+}
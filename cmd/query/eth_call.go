@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/bcdevtools/devd/v3/cmd/flags"
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 )
@@ -19,19 +21,40 @@ func GetQueryEvmRpcEthCallCommand() *cobra.Command {
 	const flagValue = "value"
 
 	cmd := &cobra.Command{
-		Use:   "eth_call [contract address] [call data]",
+		Use:   "eth_call [contract address] [call data | method signature] [?method args..]",
 		Short: "Call `eth_call` of EVM RPC: executes a new EVM message call immediately without creating a transaction on the block chain",
-		Long: `Call "eth_call" of EVM RPC: executes a new EVM message call immediately without creating a transaction on the block chain.
-Bech32 account address is accepted.`,
-		Args: cobra.ExactArgs(2),
+		Long: fmt.Sprintf(`Call "eth_call" of EVM RPC: executes a new EVM message call immediately without creating a transaction on the block chain.
+Bech32 account address is accepted.
+
+The call data can be provided in one of the following ways:
+- Raw hex call data: eth_call 0xContract 0x70a08231...
+- Method signature with outputs, followed by the arguments: eth_call 0xContract 'balanceOf(address)(uint256)' 0xAccount
+- ABI file and method name, followed by the arguments: eth_call 0xContract 0xAccount --%s erc20.json --%s balanceOf
+When method is provided, the arguments will be ABI-encoded and the returned data will be decoded.
+Arguments: address accepts 0x/bech32, numbers accept short int (1e18, 5m) and hex, arrays and tuples are JSON arrays.`, flags.FlagAbi, flags.FlagMethod),
+		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			ethClient, _ := flags.MustGetEthClient(cmd)
 
 			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args[0])
 			utils.ExitOnErr(err, "failed to parse contract address")
 			contractAddress := evmAddrs[0]
-			callData, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(args[1]), "0x"))
-			utils.ExitOnErr(err, "failed to decode call data")
+
+			var callData []byte
+			var method *abi.Method
+			if methodName, _ := cmd.Flags().GetString(flags.FlagMethod); methodName == "" && len(args) == 2 && !strings.Contains(args[1], "(") {
+				callData, err = hex.DecodeString(strings.TrimPrefix(strings.ToLower(args[1]), "0x"))
+				utils.ExitOnErr(err, "failed to decode call data")
+			} else {
+				abiMethod, methodArgs, err := flags.ReadAbiMethodAndArgs(cmd, args[1:])
+				utils.ExitOnErr(err, "failed to resolve method")
+				method = &abiMethod
+
+				utils.PrintlnStdErr("INF: calling method", method.Sig)
+
+				callData, err = utils.AbiPackMethodCall(abiMethod, methodArgs)
+				utils.ExitOnErr(err, "failed to encode call data")
+			}
 
 			contextHeight := func() *big.Int {
 				height, err := flags.ReadFlagBlockNumberOrNil(cmd, flags.FlagHeight)
@@ -89,7 +112,29 @@ Bech32 account address is accepted.`,
 			}, contextHeight)
 			utils.ExitOnErr(err, "failed to call contract")
 
-			fmt.Println("0x" + hex.EncodeToString(result))
+			if method == nil || len(method.Outputs) == 0 {
+				utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]string{
+					"result": "0x" + hex.EncodeToString(result),
+				}, func() {
+					fmt.Println("0x" + hex.EncodeToString(result))
+				})
+				return
+			}
+
+			decoded, err := utils.AbiUnpackMethodOutputs(*method, result)
+			utils.ExitOnErr(err, "failed to decode result")
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]any{
+				"result":  "0x" + hex.EncodeToString(result),
+				"decoded": decoded,
+			}, func() {
+				fmt.Println("0x" + hex.EncodeToString(result))
+				for _, output := range decoded {
+					bz, err := json.Marshal(output.Value)
+					utils.ExitOnErr(err, "failed to marshal output")
+					fmt.Printf("%s (%s): %s\n", output.Name, output.Type, string(bz))
+				}
+			})
 		},
 	}
 
@@ -99,6 +144,8 @@ Bech32 account address is accepted.`,
 	cmd.Flags().StringP(flags.FlagGasPrices, "p", "", "gas prices used for each paid gas, support short int and hex")
 	cmd.Flags().StringP(flagValue, "v", "", "value sent with this transaction, support short int and hex")
 	cmd.Flags().StringP(flags.FlagHeight, "h", "latest", "the context height of the block to exec, accept \"latest\"/short int/hex")
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc)
+	cmd.Flags().String(flags.FlagMethod, "", flags.FlagMethodDesc)
 
 	return cmd
 }
@@ -20,7 +20,11 @@ func GetQueryEvmRpcEthChainIdCommand() *cobra.Command {
 			chainId, err := ethClient.ChainID(context.Background())
 			utils.ExitOnErr(err, "failed to get chain id")
 
-			fmt.Println(chainId.String())
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), map[string]string{
+				"chainId": chainId.String(),
+			}, func() {
+				fmt.Println(chainId.String())
+			})
 		},
 	}
 
@@ -3,7 +3,6 @@ package query
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
@@ -38,14 +37,7 @@ func GetQueryEvmRpcEthGetAccountCommand() *cobra.Command {
 			utils.ExitOnErr(err, "failed to create json rpc query param")
 			params = append(params, paramsAddr)
 
-			paramsContext, err := types.NewJsonRpcStringQueryParam(func() string {
-				if contextHeight == nil {
-					return "latest"
-				}
-				return contextHeight.Text(16)
-			}())
-			utils.ExitOnErr(err, "failed to create json rpc query param")
-			params = append(params, paramsContext)
+			params = append(params, types.NewJsonRpcBlockTagQueryParam(contextHeight))
 
 			bz, err := types.DoEvmRpcQuery(
 				evmRpc,
@@ -117,10 +109,7 @@ func GetQueryEvmRpcEthGetAccountCommand() *cobra.Command {
 				}
 			}
 
-			bz, err = json.Marshal(accountInfoAsMap)
-			utils.ExitOnErr(err, "failed to marshal account info")
-
-			utils.TryPrintBeautyJson(bz)
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), accountInfoAsMap, nil)
 		},
 	}
 
@@ -56,21 +56,11 @@ func GetQueryEvmRpcEthGetBlockByNumberCommand() *cobra.Command {
 				blockNumber = nil
 			}
 
-			var paramBlockNumber types.JsonRpcQueryParam
-			var err error
-
-			if blockNumber == nil || blockNumber.Sign() == 0 {
-				paramBlockNumber, err = types.NewJsonRpcStringQueryParam("latest")
-				utils.ExitOnErr(err, "failed to create json rpc query param")
-			} else {
-				paramBlockNumber = types.NewJsonRpcInt64QueryParam(blockNumber.Int64())
-			}
-
 			bz, err := types.DoEvmRpcQuery(
 				evmRpc,
 				types.NewJsonRpcQueryBuilder(
 					"eth_getBlockByNumber",
-					paramBlockNumber,
+					types.NewJsonRpcBlockTagQueryParam(blockNumber),
 					types.NewJsonRpcBoolQueryParam(cmd.Flag(flagFull).Changed),
 				),
 				0,
@@ -80,17 +70,9 @@ func GetQueryEvmRpcEthGetBlockByNumberCommand() *cobra.Command {
 			blockInfoAsMap, err := getResultObjectFromEvmRpcResponse(bz)
 			utils.ExitOnErr(err, "failed to get result object from response")
 
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "baseFeePerGas")
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "gasLimit")
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "gasUsed")
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "number")
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "size")
-			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, "timestamp")
+			injectTranslatedFieldsOfEvmBlock(blockInfoAsMap)
 
-			bz, err = json.Marshal(blockInfoAsMap)
-			utils.ExitOnErr(err, "failed to marshal response block by number")
-
-			utils.TryPrintBeautyJson(bz)
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), blockInfoAsMap, nil)
 		},
 	}
 
@@ -100,6 +82,12 @@ func GetQueryEvmRpcEthGetBlockByNumberCommand() *cobra.Command {
 	return cmd
 }
 
+func injectTranslatedFieldsOfEvmBlock(blockInfoAsMap map[string]interface{}) {
+	for _, key := range []string{"baseFeePerGas", "gasLimit", "gasUsed", "number", "size", "timestamp"} {
+		utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Block{}, blockInfoAsMap, key)
+	}
+}
+
 func getResultObjectFromEvmRpcResponse(bz []byte) (map[string]interface{}, error) {
 	var _map map[string]interface{}
 	err := json.Unmarshal(bz, &_map)
@@ -0,0 +1,273 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagAddress   = "address"
+	flagTopic     = "topic"
+	flagFromBlock = "from-block"
+	flagToBlock   = "to-block"
+	flagPageSize  = "page-size"
+)
+
+const defaultLogsPageSize = 2000
+
+func GetQueryEvmRpcEthGetLogsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "eth_getLogs",
+		Aliases: []string{"evm-logs"},
+		Short:   "Query `eth_getLogs` from EVM RPC, with optional event decoding.",
+		Long: fmt.Sprintf(`Query "eth_getLogs" from EVM RPC.
+Large block range will be split into pages of --%s blocks, page size will be reduced automatically when the node rejects the range.
+
+Flag --%s is position-based, the first one is topic 0 (event signature), the second one is topic 1,...
+Each topic can be:
+- an event signature like 'Transfer(address indexed from, address indexed to, uint256 value)', only for topic 0, it will be hashed.
+- a 32 bytes hash.
+- an address, it will be left-padded into 32 bytes.
+- '*' or empty for any.
+Multiple values of the same position can be separated by '|'.
+
+Logs will be decoded if the event signature is provided via topic 0, or the ABI file is provided via --%s.`, flagPageSize, flagTopic, flags.FlagAbi),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ethClient8545, evmRpc := flags.MustGetEthClient(cmd)
+
+			addresses, topics, knownEvents := mustReadLogFilterFlags(cmd)
+
+			fromBlock, err := flags.ReadFlagBlockNumberOrNil(cmd, flagFromBlock)
+			utils.ExitOnErr(err, "failed to parse from block")
+
+			toBlock, err := flags.ReadFlagBlockNumberOrNil(cmd, flagToBlock)
+			utils.ExitOnErr(err, "failed to parse to block")
+
+			if fromBlock == nil || toBlock == nil {
+				latestBlock, err := ethClient8545.BlockNumber(context.Background())
+				utils.ExitOnErr(err, "failed to get latest block number")
+				if toBlock == nil {
+					toBlock = new(big.Int).SetUint64(latestBlock)
+				}
+				if fromBlock == nil {
+					fromBlock = new(big.Int).Set(toBlock)
+				}
+			}
+
+			if fromBlock.Cmp(toBlock) > 0 {
+				utils.PrintlnStdErr("ERR: from block", fromBlock, "is greater than to block", toBlock)
+				os.Exit(1)
+			}
+
+			pageSize, _ := cmd.Flags().GetUint64(flagPageSize)
+			if pageSize < 1 {
+				utils.PrintlnStdErr("ERR: page size must be positive")
+				os.Exit(1)
+			}
+
+			var logs []ethtypes.Log
+			for cur := new(big.Int).Set(fromBlock); cur.Cmp(toBlock) <= 0; {
+				end := new(big.Int).Add(cur, new(big.Int).SetUint64(pageSize-1))
+				if end.Cmp(toBlock) > 0 {
+					end = new(big.Int).Set(toBlock)
+				}
+
+				pageLogs, err := fetchEvmLogs(evmRpc, ethGetLogsFilter{
+					FromBlock: (*hexutil.Big)(cur),
+					ToBlock:   (*hexutil.Big)(end),
+					Address:   addresses,
+					Topics:    topics,
+				})
+				if err != nil {
+					if pageSize > 1 && utils.IsEvmLogsQueryRangeError(err) {
+						pageSize /= 2
+						utils.PrintlnStdErr("WARN: range rejected, reduce page size to", pageSize, "blocks:", err)
+						continue
+					}
+					utils.ExitOnErr(err, "failed to get logs")
+				}
+
+				logs = append(logs, pageLogs...)
+				cur = end.Add(end, common.Big1)
+			}
+
+			utils.PrintlnStdErr("INF: Found", len(logs), "logs")
+
+			outputLogs := make([]map[string]any, 0, len(logs))
+			for _, log := range logs {
+				outputLogs = append(outputLogs, mustDecodeLogForOutput(log, knownEvents))
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), outputLogs, func() {
+				printRow := func(colBlock, colTxHash, colIndex, colAddress, colEvent string) {
+					fmt.Printf("%-10s | %66s | %5s | %42s | %-1s\n", colBlock, colTxHash, colIndex, colAddress, colEvent)
+				}
+
+				printRow("Block", "Tx Hash", "Index", "Address", "Event")
+
+				for i, log := range logs {
+					event := "-"
+					if sig, found := outputLogs[i]["_event"]; found {
+						event = sig.(string)
+					} else if len(log.Topics) > 0 {
+						event = log.Topics[0].Hex()
+					}
+					printRow(fmt.Sprintf("%d", log.BlockNumber), log.TxHash.Hex(), fmt.Sprintf("%d", log.Index), log.Address.Hex(), event)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().StringSlice(flagAddress, []string{}, "filter logs by emitter contract address, 0x or bech32")
+	cmd.Flags().StringArray(flagTopic, []string{}, "filter logs by topic, position-based, the first one is topic 0 (event signature)")
+	cmd.Flags().String(flagFromBlock, "", "from block, default is the to block")
+	cmd.Flags().String(flagToBlock, "", "to block, default is the latest block")
+	cmd.Flags().Uint64(flagPageSize, defaultLogsPageSize, "maximum number of blocks per request")
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc+", used to decode logs")
+
+	return cmd
+}
+
+// mustReadLogFilterFlags reads the --address, --topic and --abi flags,
+// returns the known events from topic 0 event signatures and the ABI, used to decode logs.
+func mustReadLogFilterFlags(cmd *cobra.Command) (addresses []common.Address, topics [][]common.Hash, knownEvents map[common.Hash]abi.Event) {
+	addressesStr, _ := cmd.Flags().GetStringSlice(flagAddress)
+	addresses, err := utils.GetEvmAddressFromAnyFormatAddress(addressesStr...)
+	utils.ExitOnErr(err, "failed to parse address")
+
+	knownEvents = make(map[common.Hash]abi.Event)
+
+	topicsStr, _ := cmd.Flags().GetStringArray(flagTopic)
+	for position, topicStr := range topicsStr {
+		topic, events, err := parseLogTopicFilter(position, topicStr)
+		utils.ExitOnErr(err, fmt.Sprintf("failed to parse topic %d", position))
+		topics = append(topics, topic)
+		for _, event := range events {
+			knownEvents[event.ID] = event
+		}
+	}
+
+	contractAbi, err := flags.ReadFlagAbiOrNil(cmd)
+	utils.ExitOnErr(err, "failed to read ABI file")
+	if contractAbi != nil {
+		for _, event := range contractAbi.Events {
+			if _, found := knownEvents[event.ID]; !found {
+				knownEvents[event.ID] = event
+			}
+		}
+	}
+
+	return
+}
+
+// mustDecodeLogForOutput converts the log into map, with decoded event signature and arguments injected as '_event' and '_args' if the event is known.
+func mustDecodeLogForOutput(log ethtypes.Log, knownEvents map[common.Hash]abi.Event) map[string]any {
+	outputLog := make(map[string]any)
+
+	bz, err := json.Marshal(log)
+	utils.ExitOnErr(err, "failed to marshal log")
+	err = json.Unmarshal(bz, &outputLog)
+	utils.ExitOnErr(err, "failed to unmarshal log")
+
+	if len(log.Topics) > 0 {
+		if event, found := knownEvents[log.Topics[0]]; found {
+			decoded, err := utils.DecodeEvmLog(event, log.Topics, log.Data)
+			if err != nil {
+				utils.PrintlnStdErr("WARN: failed to decode log", log.Index, "of tx", log.TxHash.Hex(), ":", err)
+			} else {
+				outputLog["_event"] = event.Sig
+				outputLog["_args"] = decoded
+			}
+		}
+	}
+
+	return outputLog
+}
+
+type ethGetLogsFilter struct {
+	FromBlock *hexutil.Big     `json:"fromBlock"`
+	ToBlock   *hexutil.Big     `json:"toBlock"`
+	Address   []common.Address `json:"address,omitempty"`
+	Topics    [][]common.Hash  `json:"topics,omitempty"`
+}
+
+func fetchEvmLogs(evmRpc string, filter ethGetLogsFilter) ([]ethtypes.Log, error) {
+	paramFilter, err := types.NewJsonRpcQueryParam(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := types.DoEvmRpcQuery(
+		evmRpc,
+		types.NewJsonRpcQueryBuilder(
+			"eth_getLogs",
+			paramFilter,
+		),
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := types.ParseJsonRpcResponse[[]ethtypes.Log](bz)
+	if err != nil {
+		return nil, err
+	}
+
+	return *(res.(*[]ethtypes.Log)), nil
+}
+
+// parseLogTopicFilter parses the topic filter at the given position,
+// returns nil topic for any, and the events parsed from the event signatures.
+func parseLogTopicFilter(position int, topicStr string) (topic []common.Hash, events []abi.Event, err error) {
+	topicStr = strings.TrimSpace(topicStr)
+	if topicStr == "" || topicStr == "*" || strings.EqualFold(topicStr, "null") {
+		return nil, nil, nil
+	}
+
+	for _, alternative := range strings.Split(topicStr, "|") {
+		alternative = strings.TrimSpace(alternative)
+
+		switch {
+		case regexp.MustCompile(`^0x[a-fA-F\d]{64}$`).MatchString(alternative):
+			topic = append(topic, common.HexToHash(alternative))
+		case regexp.MustCompile(`^0x[a-fA-F\d]{40}$`).MatchString(alternative):
+			topic = append(topic, common.BytesToHash(common.HexToAddress(alternative).Bytes()))
+		case strings.Contains(alternative, "("):
+			if position != 0 {
+				err = fmt.Errorf("event signature is only accepted for topic 0: %s", alternative)
+				return
+			}
+
+			var event abi.Event
+			event, err = utils.ParseEventSignature(alternative)
+			if err != nil {
+				return
+			}
+
+			topic = append(topic, event.ID)
+			events = append(events, event)
+		default:
+			err = fmt.Errorf("invalid topic, require event signature, 32 bytes hash or address: %s", alternative)
+			return
+		}
+	}
+
+	return
+}
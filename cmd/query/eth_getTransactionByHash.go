@@ -2,7 +2,7 @@ package query
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"os"
 	"regexp"
 	"strings"
@@ -39,7 +39,7 @@ func GetQueryEvmRpcEthGetTransactionByHashCommand() *cobra.Command {
 			})
 			utils.ExitOnErr(err, "failed to marshal transaction to json")
 
-			fmt.Println(string(bz))
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
 		},
 	}
 
@@ -2,7 +2,7 @@ package query
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"os"
 	"regexp"
 	"strings"
@@ -40,7 +40,7 @@ Some fields of log like block/tx number/hash, which already available at receipt
 			})
 			utils.ExitOnErr(err, "failed to marshal receipt to json")
 
-			fmt.Println(string(bz))
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), json.RawMessage(bz), nil)
 		},
 	}
 
@@ -3,13 +3,12 @@ package query
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	acbitypes "github.com/cometbft/cometbft/abci/types"
@@ -25,7 +24,9 @@ func GetQueryTxEventsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "events [height/tx hash]",
 		Short: "Query block/tx events",
-		Args:  cobra.ExactArgs(1),
+		Long: `Query block/tx events.
+IBC voucher denoms found in attributes are resolved into traces, injected as attributes with key '_ibc/{hash}'.`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			txHashType := utils.DetectTxHashType(args[0])
 			tendermintRpcHttpClient, _ := flags.MustGetTmRpc(cmd)
@@ -75,61 +76,114 @@ func GetQueryTxEventsCommand() *cobra.Command {
 
 			events = utils.ResolveBase64Events(events)
 
-			filters, _ := cmd.Flags().GetStringSlice(flagFilter)
-			filters = func() []string {
-				uniqueFilters := make(map[string]struct{}, len(filters))
-				for _, filter := range filters {
-					if filter == "" {
-						continue
-					}
-					uniqueFilters[filter] = struct{}{}
-				}
-				return maps.Keys(uniqueFilters)
-			}()
-			containsFilterPattern := func(str string) bool {
-				for _, filter := range filters {
-					if strings.Contains(str, filter) {
-						return true
-					}
-				}
-				return false
-			}
-			if len(filters) > 0 {
-				var filteredEvents []acbitypes.Event
-				for _, event := range events {
-					var contains bool
-					if containsFilterPattern(event.Type) {
-						contains = true
-					} else {
-						for _, attr := range event.Attributes {
-							if containsFilterPattern(attr.Key) || containsFilterPattern(attr.Value) {
-								contains = true
-								break
-							}
-						}
-					}
-
-					if contains {
-						filteredEvents = append(filteredEvents, event)
-					}
-				}
+			if filters := readEventFilters(cmd); len(filters) > 0 {
+				events = filterEvents(events, filters)
 
-				if len(filteredEvents) < 1 {
+				if len(events) < 1 {
 					utils.PrintlnStdErr("ERR: no events found after filtered")
 					return
 				}
-
-				events = filteredEvents
 			}
 
-			bz, err := json.MarshalIndent(events, "", "  ")
-			utils.ExitOnErr(err, "failed to marshal events to json")
-			fmt.Println(string(bz))
+			events = injectIbcDenomTraceIntoEvents(cmd, events)
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), events, nil)
 		},
 	}
 
 	cmd.Flags().String(flags.FlagTendermintRpc, "", flags.FlagTmRpcDesc)
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc+", used to resolve IBC denom traces")
 	cmd.Flags().StringSliceP(flagFilter, "f", []string{}, "filter events, output only events which contains the filter string. If multiple filters are provided, events that contain one of the filters will be output.")
 
 	return cmd
 }
+
+// readEventFilters returns the distinct non-empty filters provided via --filter flag.
+func readEventFilters(cmd *cobra.Command) []string {
+	filters, _ := cmd.Flags().GetStringSlice(flagFilter)
+
+	uniqueFilters := make(map[string]struct{}, len(filters))
+	for _, filter := range filters {
+		if filter == "" {
+			continue
+		}
+		uniqueFilters[filter] = struct{}{}
+	}
+	return maps.Keys(uniqueFilters)
+}
+
+// filterEvents returns the events which type, any attribute key or value contains one of the filters.
+func filterEvents(events []acbitypes.Event, filters []string) []acbitypes.Event {
+	containsFilterPattern := func(str string) bool {
+		for _, filter := range filters {
+			if strings.Contains(str, filter) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var filteredEvents []acbitypes.Event
+	for _, event := range events {
+		var contains bool
+		if containsFilterPattern(event.Type) {
+			contains = true
+		} else {
+			for _, attr := range event.Attributes {
+				if containsFilterPattern(attr.Key) || containsFilterPattern(attr.Value) {
+					contains = true
+					break
+				}
+			}
+		}
+
+		if contains {
+			filteredEvents = append(filteredEvents, event)
+		}
+	}
+
+	return filteredEvents
+}
+
+// injectIbcDenomTraceIntoEvents resolves IBC voucher denoms found in attribute values,
+// then injects the trace as attribute with key '_ibc/{hash}' right after the attribute contains the denom.
+func injectIbcDenomTraceIntoEvents(cmd *cobra.Command, events []acbitypes.Event) []acbitypes.Event {
+	var resolver *ibcDenomResolver
+
+	for i, event := range events {
+		var attributes []acbitypes.EventAttribute
+		injected := make(map[string]struct{})
+		for _, attr := range event.Attributes {
+			attributes = append(attributes, attr)
+
+			for _, denom := range types.FindIbcDenoms(attr.Value) {
+				if _, found := injected[denom]; found {
+					continue
+				}
+
+				if resolver == nil {
+					rest, _ := flags.ReadCosmosRest(cmd)
+					resolver = newIbcDenomResolver(rest)
+				}
+
+				trace, ok := resolver.resolve(denom)
+				if !ok {
+					continue
+				}
+
+				injected[denom] = struct{}{}
+				attributes = append(attributes, acbitypes.EventAttribute{
+					Key:   "_" + denom,
+					Value: trace.FullPath(),
+				})
+			}
+		}
+		events[i].Attributes = attributes
+	}
+
+	if resolver != nil {
+		resolver.save()
+	}
+
+	return events
+}
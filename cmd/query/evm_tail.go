@@ -0,0 +1,446 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagHeads   = "heads"
+	flagLogs    = "logs"
+	flagPending = "pending"
+	flagEvmWs   = "evm-ws"
+	flagPoll    = "poll"
+)
+
+// evmTailMode is the subscription name of `eth_subscribe`.
+type evmTailMode string
+
+const (
+	evmTailModeHeads   evmTailMode = "newHeads"
+	evmTailModeLogs    evmTailMode = "logs"
+	evmTailModePending evmTailMode = "newPendingTransactions"
+)
+
+func GetQueryEvmTailCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evm-tail",
+		Short: "Stream new blocks, logs or pending txs as they happen, via EVM websocket",
+		Long: fmt.Sprintf(`Stream new blocks (--%s, default), logs (--%s) or pending txs (--%s) as they happen, via EVM websocket 'eth_subscribe'.
+The websocket endpoint is derived from the EVM Json-RPC endpoint (http => ws, port 8545 => 8546), or provided via --%s.
+If the websocket is not available, falls back to polling the EVM Json-RPC every --%s, use --%s to always poll.
+Logs can be filtered by --%s and --%s (same as 'eth_getLogs'), pending txs can be filtered by --%s (sender or recipient).
+Press Ctrl+C to stop.`, flagHeads, flagLogs, flagPending, flagEvmWs, flagInterval, flagPoll, flagAddress, flagTopic, flagAddress),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			mode := mustReadEvmTailMode(cmd)
+
+			addresses, topics, knownEvents := mustReadLogFilterFlags(cmd)
+			if mode == evmTailModeHeads && (len(addresses) > 0 || len(topics) > 0) {
+				utils.PrintlnStdErr(fmt.Sprintf("ERR: --%s and --%s are not supported when streaming new blocks", flagAddress, flagTopic))
+				os.Exit(1)
+			}
+			if mode == evmTailModePending && len(topics) > 0 {
+				utils.PrintlnStdErr(fmt.Sprintf("ERR: --%s is not supported when streaming pending txs", flagTopic))
+				os.Exit(1)
+			}
+
+			interval, err := cmd.Flags().GetDuration(flagInterval)
+			utils.ExitOnErr(err, "failed to parse interval")
+			if interval <= 0 {
+				utils.PrintlnStdErr("ERR: interval must be positive")
+				os.Exit(1)
+			}
+
+			evmRpc, inputSource := flags.ReadEvmRpc(cmd)
+			utils.PrintlnStdErr("INF: Connecting to EVM Json-RPC", evmRpc, fmt.Sprintf("(from %s)", inputSource))
+
+			rpcClient, err := rpc.Dial(evmRpc)
+			utils.ExitOnErr(err, "failed to connect to EVM Json-RPC")
+			defer rpcClient.Close()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			tailer := &evmTailer{
+				mode:         mode,
+				rpcClient:    rpcClient,
+				addresses:    addresses,
+				topics:       topics,
+				knownEvents:  knownEvents,
+				outputFormat: flags.ReadFlagOutputFormat(cmd),
+			}
+
+			if poll, _ := cmd.Flags().GetBool(flagPoll); !poll {
+				evmWs, _ := cmd.Flags().GetString(flagEvmWs)
+				evmWs = strings.TrimSpace(evmWs)
+				if evmWs == "" {
+					evmWs, err = utils.GetEvmWsEndpointFromEvmRpc(evmRpc)
+				}
+
+				if err == nil {
+					err = tailer.subscribe(ctx, evmWs)
+				}
+				if err == nil {
+					utils.PrintlnStdErr("INF: stopped")
+					return
+				}
+
+				utils.PrintlnStdErr("WARN:", err)
+				utils.PrintlnStdErr("WARN: falling back to polling EVM Json-RPC every", interval)
+			}
+
+			tailer.poll(ctx, interval)
+			utils.PrintlnStdErr("INF: stopped")
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().String(flagEvmWs, "", "EVM websocket endpoint, default is derived from the EVM Json-RPC endpoint")
+	cmd.Flags().Bool(flagHeads, false, "stream new blocks (default)")
+	cmd.Flags().Bool(flagLogs, false, "stream new logs")
+	cmd.Flags().Bool(flagPending, false, "stream new pending txs")
+	cmd.Flags().StringSlice(flagAddress, []string{}, "filter logs by emitter contract address, or pending txs by sender/recipient, 0x or bech32")
+	cmd.Flags().StringArray(flagTopic, []string{}, "filter logs by topic, position-based, the first one is topic 0 (event signature)")
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc+", used to decode logs")
+	cmd.Flags().Bool(flagPoll, false, "always poll the EVM Json-RPC instead of subscribing via websocket")
+	cmd.Flags().Duration(flagInterval, 2*time.Second, "polling interval, used when websocket is not available")
+
+	cmd.MarkFlagsMutuallyExclusive(flagHeads, flagLogs, flagPending)
+
+	return cmd
+}
+
+func mustReadEvmTailMode(cmd *cobra.Command) evmTailMode {
+	if logs, _ := cmd.Flags().GetBool(flagLogs); logs {
+		return evmTailModeLogs
+	}
+	if pending, _ := cmd.Flags().GetBool(flagPending); pending {
+		return evmTailModePending
+	}
+	return evmTailModeHeads
+}
+
+// evmTailer streams the new blocks, logs or pending txs, via websocket subscription or polling.
+type evmTailer struct {
+	mode         evmTailMode
+	rpcClient    *rpc.Client
+	addresses    []common.Address
+	topics       [][]common.Hash
+	knownEvents  map[common.Hash]abi.Event
+	outputFormat utils.OutputFormat
+
+	// lastBlock is the last processed block, used to resume when falling back to polling
+	lastBlock uint64
+	// started is true once any block has been processed, lastBlock is only valid after started
+	started bool
+}
+
+// subscribe streams via websocket `eth_subscribe` until the context is done,
+// returns error if failed to subscribe or the subscription is broken.
+func (t *evmTailer) subscribe(ctx context.Context, evmWs string) error {
+	utils.PrintlnStdErr("INF: Connecting to EVM websocket", evmWs)
+
+	dialCtx, cancelDial := context.WithTimeout(ctx, 10*time.Second)
+	wsClient, err := rpc.DialContext(dialCtx, evmWs)
+	cancelDial()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to EVM websocket")
+	}
+	defer wsClient.Close()
+
+	args := []any{string(t.mode)}
+	if t.mode == evmTailModeLogs {
+		args = append(args, ethGetLogsFilter{
+			Address: t.addresses,
+			Topics:  t.topics,
+		})
+	}
+
+	notifications := make(chan json.RawMessage, 100)
+	subscribeCtx, cancelSubscribe := context.WithTimeout(ctx, 10*time.Second)
+	sub, err := wsClient.EthSubscribe(subscribeCtx, notifications, args...)
+	cancelSubscribe()
+	if err != nil {
+		return errors.Wrapf(err, "failed to subscribe %s via EVM websocket", t.mode)
+	}
+	defer sub.Unsubscribe()
+
+	utils.PrintlnStdErr("INF: subscribed to", t.mode)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("closed")
+			}
+			return errors.Wrap(err, "EVM websocket subscription is broken")
+		case notification := <-notifications:
+			t.handleNotification(ctx, notification)
+		}
+	}
+}
+
+func (t *evmTailer) handleNotification(ctx context.Context, notification json.RawMessage) {
+	switch t.mode {
+	case evmTailModeHeads:
+		var header struct {
+			Number hexutil.Uint64 `json:"number"`
+		}
+		if err := json.Unmarshal(notification, &header); err != nil {
+			utils.PrintlnStdErr("WARN: failed to unmarshal new head:", err)
+			return
+		}
+		t.printBlock(ctx, uint64(header.Number))
+		t.markBlockProcessed(uint64(header.Number))
+	case evmTailModeLogs:
+		var log ethtypes.Log
+		if err := json.Unmarshal(notification, &log); err != nil {
+			utils.PrintlnStdErr("WARN: failed to unmarshal log:", err)
+			return
+		}
+		t.printLog(log)
+		t.markBlockProcessed(log.BlockNumber)
+	case evmTailModePending:
+		var txHash common.Hash
+		if err := json.Unmarshal(notification, &txHash); err != nil {
+			utils.PrintlnStdErr("WARN: failed to unmarshal pending tx hash:", err)
+			return
+		}
+		t.printPendingTx(ctx, txHash)
+	}
+}
+
+// poll streams by polling the EVM Json-RPC until the context is done.
+func (t *evmTailer) poll(ctx context.Context, interval time.Duration) {
+	var pendingTxsFilterId string
+
+	utils.PrintlnStdErr("INF: polling", t.mode)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		switch t.mode {
+		case evmTailModeHeads, evmTailModeLogs:
+			t.pollBlocks(ctx)
+		case evmTailModePending:
+			pendingTxsFilterId = t.pollPendingTxs(ctx, pendingTxsFilterId)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollBlocks processes the blocks from the last processed block to the latest block,
+// starting from the latest block if nothing has been processed yet.
+func (t *evmTailer) pollBlocks(ctx context.Context) {
+	var latest hexutil.Uint64
+	if err := t.rpcClient.CallContext(ctx, &latest, "eth_blockNumber"); err != nil {
+		if ctx.Err() == nil {
+			utils.PrintlnStdErr("WARN: failed to fetch latest block number:", err)
+		}
+		return
+	}
+
+	fromBlock := uint64(latest)
+	if t.started {
+		if uint64(latest) <= t.lastBlock {
+			return
+		}
+		fromBlock = t.lastBlock + 1
+	}
+
+	if t.mode == evmTailModeLogs {
+		var logs []ethtypes.Log
+		err := t.rpcClient.CallContext(ctx, &logs, "eth_getLogs", ethGetLogsFilter{
+			FromBlock: (*hexutil.Big)(new(big.Int).SetUint64(fromBlock)),
+			ToBlock:   (*hexutil.Big)(new(big.Int).SetUint64(uint64(latest))),
+			Address:   t.addresses,
+			Topics:    t.topics,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				utils.PrintlnStdErr("WARN: failed to fetch logs:", err)
+			}
+			return
+		}
+		for _, log := range logs {
+			t.printLog(log)
+		}
+		t.markBlockProcessed(uint64(latest))
+		return
+	}
+
+	for blockNumber := fromBlock; blockNumber <= uint64(latest); blockNumber++ {
+		if ctx.Err() != nil {
+			return
+		}
+		t.printBlock(ctx, blockNumber)
+		t.markBlockProcessed(blockNumber)
+	}
+}
+
+// markBlockProcessed records the last processed block, polling resumes from the next block.
+func (t *evmTailer) markBlockProcessed(blockNumber uint64) {
+	t.lastBlock = blockNumber
+	t.started = true
+}
+
+// pollPendingTxs processes the new pending txs using a pending txs filter,
+// the filter is created if not exists or expired. Returns the filter id to be used in the next poll.
+func (t *evmTailer) pollPendingTxs(ctx context.Context, filterId string) string {
+	if filterId == "" {
+		if err := t.rpcClient.CallContext(ctx, &filterId, "eth_newPendingTransactionFilter"); err != nil {
+			if ctx.Err() == nil {
+				utils.PrintlnStdErr("WARN: failed to create pending txs filter:", err)
+			}
+			return ""
+		}
+	}
+
+	var txHashes []common.Hash
+	if err := t.rpcClient.CallContext(ctx, &txHashes, "eth_getFilterChanges", filterId); err != nil {
+		if ctx.Err() == nil {
+			utils.PrintlnStdErr("WARN: failed to fetch pending txs:", err)
+		}
+		return "" // filter might be expired, re-create in the next poll
+	}
+
+	for _, txHash := range txHashes {
+		t.printPendingTx(ctx, txHash)
+	}
+
+	return filterId
+}
+
+// printBlock prints summary of the block, with translated fields like 'eth_getBlockByNumber'.
+func (t *evmTailer) printBlock(ctx context.Context, blockNumber uint64) {
+	var blockInfoAsMap map[string]interface{}
+	err := t.rpcClient.CallContext(ctx, &blockInfoAsMap, "eth_getBlockByNumber", hexutil.EncodeUint64(blockNumber), false)
+	if err == nil && blockInfoAsMap == nil {
+		err = errors.New("block not found")
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			utils.PrintlnStdErr("WARN: failed to fetch block", blockNumber, ":", err)
+		}
+		return
+	}
+
+	summary := make(map[string]interface{})
+	for _, key := range []string{"number", "hash", "timestamp", "miner", "gasLimit", "gasUsed", "baseFeePerGas"} {
+		if value, found := blockInfoAsMap[key]; found {
+			summary[key] = value
+		}
+	}
+	var txs int
+	if transactions, ok := blockInfoAsMap["transactions"].([]interface{}); ok {
+		txs = len(transactions)
+	}
+	summary["txs"] = txs
+	injectTranslatedFieldsOfEvmBlock(summary)
+
+	utils.PrintOutput(t.outputFormat, summary, func() {
+		line := fmt.Sprintf("Block %v | %v | txs: %d | gas used: %v/%v", summary["_number"], summary["hash"], txs, summary["_gasUsed"], summary["_gasLimit"])
+		if baseFee, found := summary["_baseFeePerGas"]; found {
+			line += fmt.Sprintf(" | base fee: %v", baseFee)
+		}
+		if timestamp, found := summary["_timestamp"]; found {
+			line += fmt.Sprintf(" | time: %v", timestamp)
+		}
+		fmt.Println(line)
+	})
+}
+
+// printLog prints the log, with decoded event if known.
+func (t *evmTailer) printLog(log ethtypes.Log) {
+	if log.Removed {
+		utils.PrintlnStdErr("WARN: log", log.Index, "of tx", log.TxHash.Hex(), "was removed due to chain re-org")
+	}
+
+	outputLog := mustDecodeLogForOutput(log, t.knownEvents)
+
+	utils.PrintOutput(t.outputFormat, outputLog, func() {
+		line := fmt.Sprintf("Block %d | tx %s | log %d | %s", log.BlockNumber, log.TxHash.Hex(), log.Index, log.Address.Hex())
+		if event, found := outputLog["_event"]; found {
+			line += fmt.Sprintf(" | %v %v", event, outputLog["_args"])
+		} else if len(log.Topics) > 0 {
+			line += fmt.Sprintf(" | %s", log.Topics[0].Hex())
+		}
+		fmt.Println(line)
+	})
+}
+
+// printPendingTx prints the pending tx, the tx is skipped if not matching the address filter.
+// If the tx details can not be fetched, only the hash is printed when there is no address filter.
+func (t *evmTailer) printPendingTx(ctx context.Context, txHash common.Hash) {
+	var txInfoAsMap map[string]interface{}
+	err := t.rpcClient.CallContext(ctx, &txInfoAsMap, "eth_getTransactionByHash", txHash)
+	if err != nil && ctx.Err() == nil {
+		utils.PrintlnStdErr("WARN: failed to fetch pending tx", txHash.Hex(), ":", err)
+	}
+
+	if txInfoAsMap == nil {
+		if len(t.addresses) > 0 {
+			return
+		}
+		txInfoAsMap = map[string]interface{}{
+			"hash": txHash.Hex(),
+		}
+	} else {
+		if len(t.addresses) > 0 && !t.isPendingTxMatchesAddresses(txInfoAsMap) {
+			return
+		}
+		for _, key := range []string{"gas", "gasPrice", "maxFeePerGas", "maxPriorityFeePerGas", "nonce", "value"} {
+			utils.TryInjectTranslatedFieldForEvmRpcObjects(&ethtypes.Transaction{}, txInfoAsMap, key)
+		}
+	}
+
+	utils.PrintOutput(t.outputFormat, txInfoAsMap, func() {
+		line := fmt.Sprintf("Pending tx %v", txInfoAsMap["hash"])
+		if from, found := txInfoAsMap["from"]; found {
+			line += fmt.Sprintf(" | from %v | to %v | nonce %v | value %v", from, txInfoAsMap["to"], txInfoAsMap["_nonce"], txInfoAsMap["_value"])
+		}
+		fmt.Println(line)
+	})
+}
+
+func (t *evmTailer) isPendingTxMatchesAddresses(txInfoAsMap map[string]interface{}) bool {
+	for _, key := range []string{"from", "to"} {
+		addrStr, ok := txInfoAsMap[key].(string)
+		if !ok || !common.IsHexAddress(addrStr) {
+			continue
+		}
+		addr := common.HexToAddress(addrStr)
+		for _, address := range t.addresses {
+			if addr == address {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,461 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const (
+	govVersionV1      = "v1"
+	govVersionV1beta1 = "v1beta1"
+)
+
+// GovProposal is the proposal of gov v1 or v1beta1, normalized into the same format.
+type GovProposal struct {
+	Id               string            `json:"id"`
+	GovVersion       string            `json:"govVersion"`
+	Status           string            `json:"status"`
+	Title            string            `json:"title"`
+	Summary          string            `json:"summary,omitempty"`
+	Proposer         string            `json:"proposer,omitempty"`
+	Messages         []json.RawMessage `json:"messages"`
+	FinalTallyResult GovTallyResult    `json:"finalTallyResult"`
+	SubmitTime       string            `json:"submitTime"`
+	DepositEndTime   string            `json:"depositEndTime"`
+	TotalDeposit     []BankBalance     `json:"totalDeposit"`
+	VotingStartTime  string            `json:"votingStartTime"`
+	VotingEndTime    string            `json:"votingEndTime"`
+}
+
+// ShortStatus returns the proposal status without prefix, eg: PROPOSAL_STATUS_VOTING_PERIOD => voting_period
+func (p GovProposal) ShortStatus() string {
+	return strings.ToLower(strings.TrimPrefix(p.Status, "PROPOSAL_STATUS_"))
+}
+
+type GovTallyResult struct {
+	Yes        string `json:"yes"`
+	Abstain    string `json:"abstain"`
+	No         string `json:"no"`
+	NoWithVeto string `json:"noWithVeto"`
+}
+
+type GovTallyParams struct {
+	Quorum        string `json:"quorum"`
+	Threshold     string `json:"threshold"`
+	VetoThreshold string `json:"veto_threshold"`
+}
+
+type GovVoteOption struct {
+	Option string `json:"option"`
+	Weight string `json:"weight"`
+}
+
+type GovVote struct {
+	ProposalId string          `json:"proposal_id"`
+	Voter      string          `json:"voter"`
+	Option     string          `json:"option,omitempty"` // v1beta1 only, deprecated
+	Options    []GovVoteOption `json:"options"`
+}
+
+type govV1Proposal struct {
+	Id               string            `json:"id"`
+	Messages         []json.RawMessage `json:"messages"`
+	Status           string            `json:"status"`
+	FinalTallyResult govV1TallyResult  `json:"final_tally_result"`
+	SubmitTime       string            `json:"submit_time"`
+	DepositEndTime   string            `json:"deposit_end_time"`
+	TotalDeposit     []BankBalance     `json:"total_deposit"`
+	VotingStartTime  string            `json:"voting_start_time"`
+	VotingEndTime    string            `json:"voting_end_time"`
+	Metadata         string            `json:"metadata"`
+	Title            string            `json:"title"`
+	Summary          string            `json:"summary"`
+	Proposer         string            `json:"proposer"`
+}
+
+type govV1TallyResult struct {
+	YesCount        string `json:"yes_count"`
+	AbstainCount    string `json:"abstain_count"`
+	NoCount         string `json:"no_count"`
+	NoWithVetoCount string `json:"no_with_veto_count"`
+}
+
+func (t govV1TallyResult) normalize() GovTallyResult {
+	return GovTallyResult{
+		Yes:        t.YesCount,
+		Abstain:    t.AbstainCount,
+		No:         t.NoCount,
+		NoWithVeto: t.NoWithVetoCount,
+	}
+}
+
+func (p govV1Proposal) normalize() GovProposal {
+	title := p.Title
+	summary := p.Summary
+	if title == "" {
+		// gov v1 of SDK v0.46 does not have title, use the one of the legacy content if any
+		for _, msg := range p.Messages {
+			var msgExecLegacyContent struct {
+				Content struct {
+					Title       string `json:"title"`
+					Description string `json:"description"`
+				} `json:"content"`
+			}
+			if err := json.Unmarshal(msg, &msgExecLegacyContent); err == nil && msgExecLegacyContent.Content.Title != "" {
+				title = msgExecLegacyContent.Content.Title
+				summary = msgExecLegacyContent.Content.Description
+				break
+			}
+		}
+	}
+
+	return GovProposal{
+		Id:               p.Id,
+		GovVersion:       govVersionV1,
+		Status:           p.Status,
+		Title:            title,
+		Summary:          summary,
+		Proposer:         p.Proposer,
+		Messages:         p.Messages,
+		FinalTallyResult: p.FinalTallyResult.normalize(),
+		SubmitTime:       p.SubmitTime,
+		DepositEndTime:   p.DepositEndTime,
+		TotalDeposit:     p.TotalDeposit,
+		VotingStartTime:  p.VotingStartTime,
+		VotingEndTime:    p.VotingEndTime,
+	}
+}
+
+type govV1beta1Proposal struct {
+	ProposalId       string                `json:"proposal_id"`
+	Content          json.RawMessage       `json:"content"`
+	Status           string                `json:"status"`
+	FinalTallyResult govV1beta1TallyResult `json:"final_tally_result"`
+	SubmitTime       string                `json:"submit_time"`
+	DepositEndTime   string                `json:"deposit_end_time"`
+	TotalDeposit     []BankBalance         `json:"total_deposit"`
+	VotingStartTime  string                `json:"voting_start_time"`
+	VotingEndTime    string                `json:"voting_end_time"`
+}
+
+type govV1beta1TallyResult struct {
+	Yes        string `json:"yes"`
+	Abstain    string `json:"abstain"`
+	No         string `json:"no"`
+	NoWithVeto string `json:"no_with_veto"`
+}
+
+func (t govV1beta1TallyResult) normalize() GovTallyResult {
+	return GovTallyResult(t)
+}
+
+func (p govV1beta1Proposal) normalize() GovProposal {
+	var content struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	_ = json.Unmarshal(p.Content, &content)
+
+	var messages []json.RawMessage
+	if len(p.Content) > 0 && string(p.Content) != "null" {
+		messages = append(messages, p.Content)
+	}
+
+	return GovProposal{
+		Id:               p.ProposalId,
+		GovVersion:       govVersionV1beta1,
+		Status:           p.Status,
+		Title:            content.Title,
+		Summary:          content.Description,
+		Messages:         messages,
+		FinalTallyResult: p.FinalTallyResult.normalize(),
+		SubmitTime:       p.SubmitTime,
+		DepositEndTime:   p.DepositEndTime,
+		TotalDeposit:     p.TotalDeposit,
+		VotingStartTime:  p.VotingStartTime,
+		VotingEndTime:    p.VotingEndTime,
+	}
+}
+
+// fetchGovFromRest queries the path of gov v1 module, falls back to gov v1beta1 if v1 is not supported (501 or 404).
+func fetchGovFromRest(rest, path, description string, responseV1, responseV1beta1 any) (govVersion string, statusCode int, err error) {
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/gov/v1"+path, description, responseV1)
+	if err == nil {
+		govVersion = govVersionV1
+		return
+	}
+
+	if statusCode != http.StatusNotImplemented && statusCode != http.StatusNotFound {
+		return
+	}
+
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/gov/v1beta1"+path, description, responseV1beta1)
+	if err == nil {
+		govVersion = govVersionV1beta1
+	}
+	return
+}
+
+func fetchGovProposalsFromRest(rest, status string) (proposals []GovProposal, statusCode int, err error) {
+	path := "/proposals?pagination.limit=10000&pagination.reverse=true"
+	if status != "" {
+		path += "&proposal_status=" + status
+	}
+
+	var responseV1 struct {
+		Proposals []govV1Proposal `json:"proposals"`
+	}
+	var responseV1beta1 struct {
+		Proposals []govV1beta1Proposal `json:"proposals"`
+	}
+
+	var govVersion string
+	govVersion, statusCode, err = fetchGovFromRest(rest, path, "proposals", &responseV1, &responseV1beta1)
+	if err != nil {
+		return
+	}
+
+	if govVersion == govVersionV1 {
+		for _, proposal := range responseV1.Proposals {
+			proposals = append(proposals, proposal.normalize())
+		}
+	} else {
+		for _, proposal := range responseV1beta1.Proposals {
+			proposals = append(proposals, proposal.normalize())
+		}
+	}
+	return
+}
+
+func fetchGovProposalFromRest(rest, id string) (proposal GovProposal, statusCode int, err error) {
+	var responseV1 struct {
+		Proposal govV1Proposal `json:"proposal"`
+	}
+	var responseV1beta1 struct {
+		Proposal govV1beta1Proposal `json:"proposal"`
+	}
+
+	var govVersion string
+	govVersion, statusCode, err = fetchGovFromRest(rest, "/proposals/"+id, "proposal", &responseV1, &responseV1beta1)
+	if err != nil {
+		return
+	}
+
+	if govVersion == govVersionV1 {
+		proposal = responseV1.Proposal.normalize()
+	} else {
+		proposal = responseV1beta1.Proposal.normalize()
+	}
+	return
+}
+
+// fetchGovProposalTallyFromRest queries the current tally of the proposal, which is in voting period.
+func fetchGovProposalTallyFromRest(rest, id string) (tally GovTallyResult, statusCode int, err error) {
+	var responseV1 struct {
+		Tally govV1TallyResult `json:"tally"`
+	}
+	var responseV1beta1 struct {
+		Tally govV1beta1TallyResult `json:"tally"`
+	}
+
+	var govVersion string
+	govVersion, statusCode, err = fetchGovFromRest(rest, "/proposals/"+id+"/tally", "proposal tally", &responseV1, &responseV1beta1)
+	if err != nil {
+		return
+	}
+
+	if govVersion == govVersionV1 {
+		tally = responseV1.Tally.normalize()
+	} else {
+		tally = responseV1beta1.Tally.normalize()
+	}
+	return
+}
+
+func fetchGovTallyParamsFromRest(rest string) (params GovTallyParams, statusCode int, err error) {
+	type responseStruct struct {
+		TallyParams GovTallyParams `json:"tally_params"`
+	}
+
+	var responseV1, responseV1beta1 responseStruct
+	var govVersion string
+	govVersion, statusCode, err = fetchGovFromRest(rest, "/params/tallying", "gov tally params", &responseV1, &responseV1beta1)
+	if err != nil {
+		return
+	}
+
+	if govVersion == govVersionV1 {
+		params = responseV1.TallyParams
+	} else {
+		params = responseV1beta1.TallyParams
+	}
+	return
+}
+
+func fetchGovVoteFromRest(rest, id, voter string) (vote GovVote, statusCode int, err error) {
+	type responseStruct struct {
+		Vote GovVote `json:"vote"`
+	}
+
+	var responseV1, responseV1beta1 responseStruct
+	var govVersion string
+	govVersion, statusCode, err = fetchGovFromRest(rest, "/proposals/"+id+"/votes/"+voter, "vote", &responseV1, &responseV1beta1)
+	if err != nil {
+		return
+	}
+
+	if govVersion == govVersionV1 {
+		vote = responseV1.Vote
+	} else {
+		vote = responseV1beta1.Vote
+	}
+
+	if len(vote.Options) == 0 && vote.Option != "" {
+		vote.Options = []GovVoteOption{{
+			Option: vote.Option,
+			Weight: "1",
+		}}
+	}
+	return
+}
+
+func fetchStakingBondedTokensFromRest(rest string) (bondedTokens *big.Int, statusCode int, err error) {
+	var response struct {
+		Pool struct {
+			BondedTokens string `json:"bonded_tokens"`
+		} `json:"pool"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/staking/v1beta1/pool", "staking pool", &response)
+	if err != nil {
+		return
+	}
+
+	var ok bool
+	bondedTokens, ok = new(big.Int).SetString(response.Pool.BondedTokens, 10)
+	if !ok {
+		err = fmt.Errorf("invalid bonded tokens: %s", response.Pool.BondedTokens)
+	}
+	return
+}
+
+// parseGovDec parses the decimal value of gov params, eg: 0.334000000000000000.
+// The tally params of gov v1beta1 are bytes and rendered as base64 of the decimal in integer form with 18 decimals.
+func parseGovDec(value string) (*big.Rat, bool) {
+	if dec, ok := new(big.Rat).SetString(value); ok {
+		return dec, true
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+
+	integer, ok := new(big.Int).SetString(string(bz), 10)
+	if !ok {
+		return nil, false
+	}
+
+	return new(big.Rat).SetFrac(integer, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)), true
+}
+
+type govTallyOutput struct {
+	Yes               string `json:"yes"`
+	No                string `json:"no"`
+	NoWithVeto        string `json:"noWithVeto"`
+	Abstain           string `json:"abstain"`
+	YesPercent        string `json:"yesPercent"`
+	NoPercent         string `json:"noPercent"`
+	NoWithVetoPercent string `json:"noWithVetoPercent"`
+	AbstainPercent    string `json:"abstainPercent"`
+	Turnout           string `json:"turnout,omitempty"`
+	Quorum            string `json:"quorum,omitempty"`
+	QuorumReached     *bool  `json:"quorumReached,omitempty"`
+	YesRatio          string `json:"yesRatio"`
+	Threshold         string `json:"threshold,omitempty"`
+	ThresholdReached  *bool  `json:"thresholdReached,omitempty"`
+	VetoRatio         string `json:"vetoRatio"`
+	VetoThreshold     string `json:"vetoThreshold,omitempty"`
+	Vetoed            *bool  `json:"vetoed,omitempty"`
+}
+
+// computeGovTally computes percentages of the tally, and compares against quorum/threshold/veto threshold.
+// Params and bonded tokens are optional, comparisons are omitted when not provided.
+func computeGovTally(tally GovTallyResult, params *GovTallyParams, bondedTokens *big.Int) govTallyOutput {
+	parseInt := func(value string) *big.Int {
+		integer, ok := new(big.Int).SetString(strings.SplitN(value, ".", 2)[0], 10)
+		if !ok {
+			return new(big.Int)
+		}
+		return integer
+	}
+
+	yes := parseInt(tally.Yes)
+	no := parseInt(tally.No)
+	noWithVeto := parseInt(tally.NoWithVeto)
+	abstain := parseInt(tally.Abstain)
+
+	total := new(big.Int).Add(yes, no)
+	total.Add(total, noWithVeto)
+	total.Add(total, abstain)
+
+	nonAbstain := new(big.Int).Sub(total, abstain)
+
+	output := govTallyOutput{
+		Yes:               tally.Yes,
+		No:                tally.No,
+		NoWithVeto:        tally.NoWithVeto,
+		Abstain:           tally.Abstain,
+		YesPercent:        formatRatio(ratio(yes, total)),
+		NoPercent:         formatRatio(ratio(no, total)),
+		NoWithVetoPercent: formatRatio(ratio(noWithVeto, total)),
+		AbstainPercent:    formatRatio(ratio(abstain, total)),
+		YesRatio:          formatRatio(ratio(yes, nonAbstain)),
+		VetoRatio:         formatRatio(ratio(noWithVeto, total)),
+	}
+
+	var quorum, threshold, vetoThreshold *big.Rat
+	if params != nil {
+		var ok bool
+		if quorum, ok = parseGovDec(params.Quorum); ok {
+			output.Quorum = formatRatio(quorum)
+		}
+		if threshold, ok = parseGovDec(params.Threshold); ok {
+			output.Threshold = formatRatio(threshold)
+			thresholdReached := nonAbstain.Sign() > 0 && ratio(yes, nonAbstain).Cmp(threshold) > 0
+			output.ThresholdReached = &thresholdReached
+		}
+		if vetoThreshold, ok = parseGovDec(params.VetoThreshold); ok {
+			output.VetoThreshold = formatRatio(vetoThreshold)
+			vetoed := total.Sign() > 0 && ratio(noWithVeto, total).Cmp(vetoThreshold) > 0
+			output.Vetoed = &vetoed
+		}
+	}
+
+	if bondedTokens != nil && bondedTokens.Sign() > 0 {
+		turnout := ratio(total, bondedTokens)
+		output.Turnout = formatRatio(turnout)
+		if quorum != nil {
+			quorumReached := turnout.Cmp(quorum) >= 0
+			output.QuorumReached = &quorumReached
+		}
+	}
+
+	return output
+}
+
+// ratio returns numerator/denominator, zero if denominator is zero.
+func ratio(numerator, denominator *big.Int) *big.Rat {
+	if denominator.Sign() == 0 {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(numerator, denominator)
+}
+
+// formatRatio formats the ratio into percentage, eg: 0.334 => 33.40%
+func formatRatio(ratio *big.Rat) string {
+	return new(big.Rat).Mul(ratio, big.NewRat(100, 1)).FloatString(2) + "%"
+}
@@ -0,0 +1,163 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+)
+
+// fetchIbcDenomTraceFromRest queries the trace of the IBC voucher denom hash,
+// using the denom traces endpoint of IBC transfer module, or the denoms endpoint of IBC-Go v8+.
+func fetchIbcDenomTraceFromRest(rest, hash string) (trace types.IbcDenomTrace, statusCode int, err error) {
+	trace, statusCode, err = fetchIbcDenomTraceFromRestDenomTraces(rest, hash)
+	if err == nil || statusCode == http.StatusOK || statusCode == 0 {
+		return
+	}
+
+	if traceV2, statusCodeV2, errV2 := fetchIbcDenomTraceFromRestDenoms(rest, hash); errV2 == nil {
+		return traceV2, statusCodeV2, nil
+	}
+
+	return
+}
+
+func fetchIbcDenomTraceFromRestDenomTraces(rest, hash string) (trace types.IbcDenomTrace, statusCode int, err error) {
+	var response struct {
+		DenomTrace struct {
+			Path      string `json:"path"`
+			BaseDenom string `json:"base_denom"`
+		} `json:"denom_trace"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/ibc/apps/transfer/v1/denom_traces/"+hash, "IBC denom trace", &response)
+	if err != nil {
+		return
+	}
+
+	trace = types.IbcDenomTrace{
+		Path:      response.DenomTrace.Path,
+		BaseDenom: response.DenomTrace.BaseDenom,
+	}
+	return
+}
+
+func fetchIbcDenomTraceFromRestDenoms(rest, hash string) (trace types.IbcDenomTrace, statusCode int, err error) {
+	var response struct {
+		Denom struct {
+			Base  string `json:"base"`
+			Trace []struct {
+				PortId    string `json:"port_id"`
+				ChannelId string `json:"channel_id"`
+			} `json:"trace"`
+		} `json:"denom"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/ibc/apps/transfer/v1/denoms/"+hash, "IBC denom", &response)
+	if err != nil {
+		return
+	}
+
+	var path []string
+	for _, hop := range response.Denom.Trace {
+		path = append(path, hop.PortId, hop.ChannelId)
+	}
+
+	trace = types.IbcDenomTrace{
+		Path:      strings.Join(path, "/"),
+		BaseDenom: response.Denom.Base,
+	}
+	return
+}
+
+// ibcDenomResolver resolves IBC voucher denoms into traces, using the local cache then the Rest API.
+// Resolved traces are cached, call save to persist the cache.
+type ibcDenomResolver struct {
+	rest  string
+	cache *types.IbcDenomTraceCache
+
+	// restUnavailable is true after any failure of the Rest API, to avoid repeated failures
+	restUnavailable bool
+}
+
+// newIbcDenomResolver returns a resolver, if rest is empty, only the local cache is used.
+func newIbcDenomResolver(rest string) *ibcDenomResolver {
+	resolver := &ibcDenomResolver{
+		rest:            rest,
+		restUnavailable: rest == "",
+	}
+
+	cacheFile, err := types.GetIbcDenomTraceCacheFilePath()
+	if err == nil {
+		resolver.cache, err = types.LoadIbcDenomTraceCache(cacheFile)
+	}
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to load IBC denom trace cache:", err)
+	}
+
+	return resolver
+}
+
+// resolve returns the trace of the IBC voucher denom, ok is false if the denom is not an IBC voucher or can not be resolved.
+func (r *ibcDenomResolver) resolve(denom string) (trace types.IbcDenomTrace, ok bool) {
+	hash, isIbcDenom := types.ParseIbcDenomHash(denom)
+	if !isIbcDenom || !strings.HasPrefix(denom, types.IbcDenomPrefix) {
+		return
+	}
+
+	if r.cache != nil {
+		if trace, found := r.cache.Get(hash); found {
+			return trace, true
+		}
+	}
+
+	if r.restUnavailable {
+		return
+	}
+
+	trace, statusCode, err := r.fetch(hash)
+	if err != nil {
+		switch statusCode {
+		case http.StatusNotFound, http.StatusBadRequest:
+			// not an existing IBC voucher
+		case http.StatusOK:
+			utils.PrintlnStdErr("WARN:", err)
+		default:
+			utils.PrintlnStdErr("WARN: failed to resolve IBC denom", denom, ":", err)
+			r.restUnavailable = true
+		}
+		return types.IbcDenomTrace{}, false
+	}
+
+	return trace, true
+}
+
+// fetch queries the trace of the IBC voucher denom hash from the Rest API, the trace is verified against the hash then cached.
+// Status code is 200 with error if the trace does not match the hash.
+func (r *ibcDenomResolver) fetch(hash string) (trace types.IbcDenomTrace, statusCode int, err error) {
+	trace, statusCode, err = fetchIbcDenomTraceFromRest(r.rest, hash)
+	if err != nil {
+		return
+	}
+
+	if trace.Hash() != hash {
+		err = fmt.Errorf("IBC denom trace %s does not match %s", trace.FullPath(), types.IbcDenomPrefix+hash)
+		return types.IbcDenomTrace{}, statusCode, err
+	}
+
+	if r.cache != nil {
+		_ = r.cache.Set(hash, trace)
+	}
+
+	return
+}
+
+// save persists the newly resolved traces into the local cache.
+func (r *ibcDenomResolver) save() {
+	if r.cache == nil {
+		return
+	}
+	if err := r.cache.Save(); err != nil {
+		utils.PrintlnStdErr("WARN: failed to save IBC denom trace cache:", err)
+	}
+}
@@ -0,0 +1,89 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+type ibcDenomOutput struct {
+	Denom     string `json:"denom"`
+	Hash      string `json:"hash"`
+	Path      string `json:"path"`
+	BaseDenom string `json:"baseDenom"`
+	FullPath  string `json:"fullPath"`
+}
+
+func GetQueryIbcDenomCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ibc-denom [ibc/hash | path/base denom]",
+		Short: "Resolve IBC voucher denom into trace, or compute IBC voucher denom from trace",
+		Long: `Resolve IBC voucher denom (ibc/hash) into the path and base denom, using the local cache or the Rest API.
+If the trace is provided (eg: transfer/channel-0/uatom), the IBC voucher denom is computed offline.
+Resolved traces are cached in ~/.devd/cache.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			input := strings.TrimSpace(args[0])
+
+			var trace types.IbcDenomTrace
+			if hash, ok := types.ParseIbcDenomHash(input); ok {
+				var resolved bool
+				trace, resolved = newIbcDenomResolver("").resolve(types.IbcDenomPrefix + hash)
+				if resolved {
+					utils.PrintlnStdErr("INF: resolved from local cache")
+				} else {
+					resolver := newIbcDenomResolver(flags.MustGetCosmosRest(cmd))
+
+					var statusCode int
+					var err error
+					trace, statusCode, err = resolver.fetch(hash)
+					if err != nil {
+						if statusCode == http.StatusNotFound || statusCode == http.StatusBadRequest {
+							utils.PrintlnStdErr("ERR: IBC denom trace not found for", types.IbcDenomPrefix+hash)
+						} else if statusCode == 501 {
+							utils.PrintlnStdErr("ERR: REST API does not support query IBC denom trace")
+						} else if statusCode == http.StatusOK {
+							utils.PrintlnStdErr("ERR:", err)
+						} else {
+							utils.PrintlnStdErr("ERR: failed to fetch IBC denom trace:", err)
+						}
+						os.Exit(1)
+					}
+
+					resolver.save()
+				}
+			} else {
+				trace = types.ParseIbcDenomTrace(input)
+				if trace.Path == "" {
+					utils.PrintlnStdErr("ERR: input is neither an IBC voucher denom nor a trace like transfer/channel-0/uatom")
+					os.Exit(1)
+				}
+			}
+
+			output := ibcDenomOutput{
+				Denom:     trace.IbcDenom(),
+				Hash:      trace.Hash(),
+				Path:      trace.Path,
+				BaseDenom: trace.BaseDenom,
+				FullPath:  trace.FullPath(),
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Denom:", output.Denom)
+				fmt.Println("Path:", output.Path)
+				fmt.Println("Base denom:", output.BaseDenom)
+				fmt.Println("Full path:", output.FullPath)
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
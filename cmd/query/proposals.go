@@ -0,0 +1,315 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagStatus = "status"
+)
+
+var proposalStatuses = map[string]string{
+	"deposit":  "PROPOSAL_STATUS_DEPOSIT_PERIOD",
+	"voting":   "PROPOSAL_STATUS_VOTING_PERIOD",
+	"passed":   "PROPOSAL_STATUS_PASSED",
+	"rejected": "PROPOSAL_STATUS_REJECTED",
+	"failed":   "PROPOSAL_STATUS_FAILED",
+}
+
+type proposalOutput struct {
+	GovProposal
+	MessageTypes        []string        `json:"messageTypes"`
+	TotalDepositDisplay string          `json:"totalDepositDisplay"`
+	Tally               *govTallyOutput `json:"tally,omitempty"`
+}
+
+type voteOutput struct {
+	ProposalId string          `json:"proposalId"`
+	Voter      string          `json:"voter"`
+	Options    []GovVoteOption `json:"options"`
+}
+
+func GetQueryProposalsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "proposals",
+		Aliases: []string{"props"},
+		Short:   "Get governance proposals, latest first",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := parseProposalStatus(cmd.Flag(flagStatus).Value.String())
+			utils.ExitOnErr(err, "failed to parse status")
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			proposals, statusCode, err := fetchGovProposalsFromRest(restApiEndpoint, status)
+			if err != nil {
+				if statusCode == http.StatusNotImplemented || statusCode == http.StatusNotFound {
+					utils.PrintlnStdErr("ERR: REST API does not support query proposals")
+				} else {
+					utils.PrintlnStdErr("ERR: failed to fetch proposals:", err)
+				}
+				os.Exit(1)
+			}
+
+			if proposals == nil {
+				proposals = make([]GovProposal, 0)
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), proposals, func() {
+				printRow := func(colId, colStatus, colVotingEnd, colTitle string) {
+					fmt.Printf("%-6s | %-14s | %-30s | %-1s\n", colId, colStatus, colVotingEnd, colTitle)
+				}
+
+				printRow("ID", "Status", "Voting end", "Title")
+
+				for _, proposal := range proposals {
+					printRow(proposal.Id, proposal.ShortStatus(), proposal.VotingEndTime, proposal.Title)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+	cmd.Flags().String(flagStatus, "", "filter by status: deposit, voting, passed, rejected or failed")
+
+	return cmd
+}
+
+func GetQueryProposalCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "proposal [id]",
+		Aliases: []string{"prop"},
+		Short:   "Get governance proposal details, with messages and tally",
+		Long: `Get governance proposal details, with messages and tally.
+For proposal in voting period, the current tally is computed, otherwise the final tally result is used.
+Tally percentages are compared against quorum, threshold and veto threshold from gov params.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := mustReadProposalId(args[0])
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			proposal, statusCode, err := fetchGovProposalFromRest(restApiEndpoint, id)
+			if err != nil {
+				if statusCode == http.StatusNotFound {
+					utils.PrintlnStdErr("ERR: proposal not found:", id)
+				} else if statusCode == http.StatusNotImplemented {
+					utils.PrintlnStdErr("ERR: REST API does not support query proposal")
+				} else {
+					utils.PrintlnStdErr("ERR: failed to fetch proposal:", err)
+				}
+				os.Exit(1)
+			}
+			utils.PrintlnStdErr("INF: using gov", proposal.GovVersion)
+
+			tally := proposal.FinalTallyResult
+			if proposal.Status == proposalStatuses["voting"] {
+				currentTally, _, err := fetchGovProposalTallyFromRest(restApiEndpoint, id)
+				if err != nil {
+					utils.PrintlnStdErr("WARN: failed to fetch current tally, using final tally result:", err)
+				} else {
+					tally = currentTally
+				}
+			}
+
+			var tallyParams *GovTallyParams
+			if params, _, err := fetchGovTallyParamsFromRest(restApiEndpoint); err != nil {
+				utils.PrintlnStdErr("WARN: failed to fetch gov tally params:", err)
+			} else {
+				tallyParams = &params
+			}
+
+			var bondedTokens *big.Int
+			if proposal.Status == proposalStatuses["voting"] {
+				// turnout of finished proposals can not be computed since bonded tokens changed
+				bondedTokens, _, err = fetchStakingBondedTokensFromRest(restApiEndpoint)
+				if err != nil {
+					utils.PrintlnStdErr("WARN: failed to fetch bonded tokens:", err)
+				}
+			}
+
+			formatter := newBondDenomFormatter(restApiEndpoint)
+			tallyOutput := computeGovTally(tally, tallyParams, bondedTokens)
+
+			output := proposalOutput{
+				GovProposal:         proposal,
+				MessageTypes:        getProposalMessageTypes(proposal),
+				TotalDepositDisplay: formatter.formatCoins(proposal.TotalDeposit),
+				Tally:               &tallyOutput,
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				printRow := func(key, value string) {
+					if value == "" {
+						return
+					}
+					fmt.Printf("%-18s: %s\n", key, value)
+				}
+
+				printRow("ID", output.Id)
+				printRow("Gov version", output.GovVersion)
+				printRow("Status", output.ShortStatus())
+				printRow("Title", output.Title)
+				printRow("Summary", output.Summary)
+				printRow("Proposer", output.Proposer)
+				printRow("Submit time", output.SubmitTime)
+				printRow("Deposit end time", output.DepositEndTime)
+				printRow("Total deposit", output.TotalDepositDisplay)
+				printRow("Voting start time", output.VotingStartTime)
+				printRow("Voting end time", output.VotingEndTime)
+
+				fmt.Println("\nTally:")
+				printRow("Yes", fmt.Sprintf("%s (%s)", formatter.format(tallyOutput.Yes, ""), tallyOutput.YesPercent))
+				printRow("No", fmt.Sprintf("%s (%s)", formatter.format(tallyOutput.No, ""), tallyOutput.NoPercent))
+				printRow("No with veto", fmt.Sprintf("%s (%s)", formatter.format(tallyOutput.NoWithVeto, ""), tallyOutput.NoWithVetoPercent))
+				printRow("Abstain", fmt.Sprintf("%s (%s)", formatter.format(tallyOutput.Abstain, ""), tallyOutput.AbstainPercent))
+				printRow("Turnout", formatAgainst(tallyOutput.Turnout, "quorum", tallyOutput.Quorum, tallyOutput.QuorumReached))
+				printRow("Yes ratio", formatAgainst(tallyOutput.YesRatio, "threshold", tallyOutput.Threshold, tallyOutput.ThresholdReached))
+				printRow("Veto ratio", formatAgainst(tallyOutput.VetoRatio, "veto threshold", tallyOutput.VetoThreshold, tallyOutput.Vetoed))
+
+				fmt.Println("\nMessages:")
+				for i, msg := range output.Messages {
+					bz, err := utils.BeautifyJson(msg)
+					if err != nil {
+						bz = msg
+					}
+					fmt.Printf("#%d %s\n%s\n", i, output.MessageTypes[i], string(bz))
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
+
+func GetQueryVoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote [proposal id] [0xVoter/Bech32]",
+		Short: "Get the vote of the voter on the governance proposal",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := mustReadProposalId(args[0])
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			voter := mustGetBech32AddressFromInput(cmd, restApiEndpoint, strings.TrimSpace(args[1]))
+
+			vote, statusCode, err := fetchGovVoteFromRest(restApiEndpoint, id, voter)
+			if err != nil {
+				if statusCode == http.StatusNotFound || statusCode == http.StatusBadRequest {
+					utils.PrintlnStdErr("ERR: vote not found of", voter, "on proposal", id)
+				} else if statusCode == http.StatusNotImplemented {
+					utils.PrintlnStdErr("ERR: REST API does not support query vote")
+				} else {
+					utils.PrintlnStdErr("ERR: failed to fetch vote:", err)
+				}
+				os.Exit(1)
+			}
+
+			output := voteOutput{
+				ProposalId: vote.ProposalId,
+				Voter:      vote.Voter,
+				Options:    vote.Options,
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Proposal:", output.ProposalId)
+				fmt.Println("Voter   :", output.Voter)
+				for _, option := range output.Options {
+					weight := option.Weight
+					if dec, ok := parseGovDec(weight); ok {
+						weight = formatRatio(dec)
+					}
+					fmt.Printf("- %s (%s)\n", strings.ToLower(strings.TrimPrefix(option.Option, "VOTE_OPTION_")), weight)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
+
+// parseProposalStatus parses the short status, eg: voting, or the full status, eg: PROPOSAL_STATUS_VOTING_PERIOD.
+// Empty input returns empty status.
+func parseProposalStatus(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", nil
+	}
+
+	if status, found := proposalStatuses[strings.ToLower(input)]; found {
+		return status, nil
+	}
+
+	for _, status := range proposalStatuses {
+		if strings.EqualFold(input, status) {
+			return status, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown proposal status: %s", input)
+}
+
+func mustReadProposalId(input string) string {
+	input = strings.TrimSpace(input)
+	if !regexp.MustCompile(`^\d+$`).MatchString(input) {
+		utils.PrintlnStdErr("ERR: invalid proposal id:", input)
+		os.Exit(1)
+	}
+	return input
+}
+
+// getProposalMessageTypes returns the type of each message of the proposal,
+// for legacy content, type of the content is appended.
+func getProposalMessageTypes(proposal GovProposal) []string {
+	messageTypes := make([]string, len(proposal.Messages))
+	for i, msg := range proposal.Messages {
+		var typed struct {
+			Type    string `json:"@type"`
+			Content struct {
+				Type string `json:"@type"`
+			} `json:"content"`
+		}
+		_ = json.Unmarshal(msg, &typed)
+
+		messageTypes[i] = typed.Type
+		if typed.Content.Type != "" {
+			messageTypes[i] += " (" + typed.Content.Type + ")"
+		}
+	}
+	return messageTypes
+}
+
+// formatAgainst formats the value against the target, eg: 40.00% (quorum 33.40%, reached)
+func formatAgainst(value, targetName, target string, reached *bool) string {
+	if value == "" {
+		if target == "" {
+			return ""
+		}
+		return fmt.Sprintf("- (%s %s)", targetName, target)
+	}
+	if target == "" {
+		return value
+	}
+	if reached == nil {
+		return fmt.Sprintf("%s (%s %s)", value, targetName, target)
+	}
+	if *reached {
+		return fmt.Sprintf("%s (%s %s, reached)", value, targetName, target)
+	}
+	return fmt.Sprintf("%s (%s %s, not reached)", value, targetName, target)
+}
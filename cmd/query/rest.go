@@ -0,0 +1,65 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// restBlockHeightHeader is the header used to query the Rest API at a specific height.
+const restBlockHeightHeader = "x-cosmos-block-height"
+
+// fetchJsonFromRest queries the Rest API path then unmarshals the response body into the response,
+// the description is used in error messages.
+func fetchJsonFromRest(rest, path, description string, response any) (statusCode int, err error) {
+	return fetchJsonFromRestAtHeight(rest, path, description, nil, response)
+}
+
+// fetchJsonFromRestAtHeight is the same as fetchJsonFromRest but queries at the given height, latest if height is nil.
+func fetchJsonFromRestAtHeight(rest, path, description string, height *big.Int, response any) (statusCode int, err error) {
+	var req *http.Request
+	req, err = http.NewRequest(http.MethodGet, rest+path, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create request to fetch %s", description)
+		return
+	}
+	if height != nil && height.Sign() > 0 {
+		req.Header.Set(restBlockHeightHeader, height.String())
+	}
+
+	var resp *http.Response
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to fetch %s", description)
+		return
+	}
+
+	statusCode = resp.StatusCode
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("failed to fetch %s! Status code: %d", description, resp.StatusCode)
+		return
+	}
+
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read response body of %s", description)
+		return
+	}
+
+	err = json.Unmarshal(bz, response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to unmarshal response body of %s", description)
+		return
+	}
+
+	return
+}
@@ -18,13 +18,26 @@ func Commands() *cobra.Command {
 		GetQueryBalanceCommand(),
 		GetQueryTxsInBlockCommand(),
 		GetQueryTxEventsCommand(),
+		GetQueryTailCommand(),
+		GetQueryEvmTailCommand(),
+		GetQueryIbcDenomCommand(),
+		GetQueryValidatorsCommand(),
+		GetQueryValidatorCommand(),
+		GetQueryDelegationsCommand(),
+		GetQueryProposalsCommand(),
+		GetQueryProposalCommand(),
+		GetQueryVoteCommand(),
+		GetQueryUpgradeCommand(),
 		GetQueryEvmRpcEthGetTransactionByHashCommand(),
 		GetQueryEvmRpcEthGetTransactionReceiptCommand(),
 		GetQueryEvmRpcEthGetBlockByNumberCommand(),
 		GetQueryEvmRpcEthChainIdCommand(),
 		GetQueryEvmRpcEthCallCommand(),
 		GetQueryEvmRpcEthGetAccountCommand(),
+		GetQueryEvmRpcEthGetLogsCommand(),
 		GetQueryEvmRpcDebugTraceTransactionCommand(),
+		GetQueryEvmRpcDebugTraceBlockByNumberCommand(),
+		GetQueryEvmRpcDebugTraceCallCommand(),
 		// fake command for deprecated alias
 		GetDeprecatedAliasBlockAsCommand(),
 		GetDeprecatedAliasTxAsCommand(),
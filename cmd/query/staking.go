@@ -0,0 +1,274 @@
+package query
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+type StakingValidator struct {
+	OperatorAddress string `json:"operator_address"`
+	ConsensusPubkey struct {
+		Type string `json:"@type"`
+		Key  string `json:"key"`
+	} `json:"consensus_pubkey"`
+	Jailed          bool   `json:"jailed"`
+	Status          string `json:"status"`
+	Tokens          string `json:"tokens"`
+	DelegatorShares string `json:"delegator_shares"`
+	Description     struct {
+		Moniker         string `json:"moniker"`
+		Identity        string `json:"identity"`
+		Website         string `json:"website"`
+		SecurityContact string `json:"security_contact"`
+		Details         string `json:"details"`
+	} `json:"description"`
+	UnbondingHeight string `json:"unbonding_height"`
+	UnbondingTime   string `json:"unbonding_time"`
+	Commission      struct {
+		CommissionRates struct {
+			Rate          string `json:"rate"`
+			MaxRate       string `json:"max_rate"`
+			MaxChangeRate string `json:"max_change_rate"`
+		} `json:"commission_rates"`
+		UpdateTime string `json:"update_time"`
+	} `json:"commission"`
+	MinSelfDelegation string `json:"min_self_delegation"`
+}
+
+// ShortStatus returns the bond status without prefix, eg: BOND_STATUS_BONDED => bonded
+func (v StakingValidator) ShortStatus() string {
+	return strings.ToLower(strings.TrimPrefix(v.Status, "BOND_STATUS_"))
+}
+
+// ValconsAddress returns the bech32 consensus address, HRP is derived from the operator address.
+func (v StakingValidator) ValconsAddress() (string, error) {
+	hrp, _, err := bech32.DecodeAndConvert(v.OperatorAddress)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(v.ConsensusPubkey.Key)
+	if err != nil {
+		return "", err
+	}
+
+	consAddr, err := utils.GetConsensusAddressFromPubKey(v.ConsensusPubkey.Type, key)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.ConvertAndEncode(strings.TrimSuffix(hrp, "valoper")+"valcons", consAddr)
+}
+
+func fetchStakingValidatorsFromRest(rest string) (validators []StakingValidator, statusCode int, err error) {
+	var response struct {
+		Validators []StakingValidator `json:"validators"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/staking/v1beta1/validators?pagination.limit=10000", "validators", &response)
+	validators = response.Validators
+	return
+}
+
+type StakingDelegation struct {
+	Delegation struct {
+		DelegatorAddress string `json:"delegator_address"`
+		ValidatorAddress string `json:"validator_address"`
+		Shares           string `json:"shares"`
+	} `json:"delegation"`
+	Balance BankBalance `json:"balance"`
+}
+
+func fetchStakingDelegationsFromRest(rest, delegator string) (delegations []StakingDelegation, statusCode int, err error) {
+	var response struct {
+		DelegationResponses []StakingDelegation `json:"delegation_responses"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/staking/v1beta1/delegations/"+delegator+"?pagination.limit=10000", "delegations", &response)
+	delegations = response.DelegationResponses
+	return
+}
+
+type StakingUnbondingDelegation struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	Entries          []struct {
+		CreationHeight string `json:"creation_height"`
+		CompletionTime string `json:"completion_time"`
+		InitialBalance string `json:"initial_balance"`
+		Balance        string `json:"balance"`
+	} `json:"entries"`
+}
+
+func fetchStakingUnbondingDelegationsFromRest(rest, delegator string) (unbondingDelegations []StakingUnbondingDelegation, statusCode int, err error) {
+	var response struct {
+		UnbondingResponses []StakingUnbondingDelegation `json:"unbonding_responses"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/staking/v1beta1/delegators/"+delegator+"/unbonding_delegations?pagination.limit=10000", "unbonding delegations", &response)
+	unbondingDelegations = response.UnbondingResponses
+	return
+}
+
+type DistributionDelegationReward struct {
+	ValidatorAddress string        `json:"validator_address"`
+	Reward           []BankBalance `json:"reward"`
+}
+
+func fetchDistributionDelegationRewardsFromRest(rest, delegator string) (rewards []DistributionDelegationReward, statusCode int, err error) {
+	var response struct {
+		Rewards []DistributionDelegationReward `json:"rewards"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/distribution/v1beta1/delegators/"+delegator+"/rewards", "delegation rewards", &response)
+	rewards = response.Rewards
+	return
+}
+
+func fetchStakingBondDenomFromRest(rest string) (bondDenom string, statusCode int, err error) {
+	var response struct {
+		Params struct {
+			BondDenom string `json:"bond_denom"`
+		} `json:"params"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/staking/v1beta1/params", "staking params", &response)
+	bondDenom = response.Params.BondDenom
+	return
+}
+
+// mustFetchStakingValidators fetches all validators, sorted by tokens descending.
+func mustFetchStakingValidators(rest string) []StakingValidator {
+	validators, statusCode, err := fetchStakingValidatorsFromRest(rest)
+	if err != nil {
+		if statusCode == 501 {
+			utils.PrintlnStdErr("ERR: REST API does not support query validators")
+		} else {
+			utils.PrintlnStdErr("ERR: failed to fetch validators:", err)
+		}
+		os.Exit(1)
+	}
+
+	sort.SliceStable(validators, func(i, j int) bool {
+		ti, _ := new(big.Int).SetString(validators[i].Tokens, 10)
+		tj, _ := new(big.Int).SetString(validators[j].Tokens, 10)
+		if ti == nil || tj == nil {
+			return ti != nil
+		}
+		return ti.Cmp(tj) > 0
+	})
+
+	return validators
+}
+
+// bondDenomFormatter formats amount of the bond denom using exponent from the denom metadata.
+type bondDenomFormatter struct {
+	denom    string
+	symbol   string
+	exponent int
+}
+
+// newBondDenomFormatter queries the bond denom and its metadata, failures are ignored and amounts will be shown as raw.
+func newBondDenomFormatter(rest string) bondDenomFormatter {
+	bondDenom, _, err := fetchStakingBondDenomFromRest(rest)
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to fetch bond denom, amounts are shown as raw:", err)
+		return bondDenomFormatter{}
+	}
+
+	formatter := bondDenomFormatter{
+		denom:  bondDenom,
+		symbol: bondDenom,
+	}
+
+	denomsMetadata, _, err := fetchDenomsMetadataFromRest(rest)
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to fetch denoms metadata, amounts are shown as raw:", err)
+		return formatter
+	}
+
+	if metadata, found := denomsMetadata[bondDenom]; found {
+		formatter.symbol = metadata.DisplaySymbol()
+		formatter.exponent = metadata.DisplayExponent()
+	}
+
+	return formatter
+}
+
+// format returns display amount of the denom, decimal part of DecCoin amount is truncated.
+// Amount of other denoms are shown as raw.
+func (f bondDenomFormatter) format(amount, denom string) string {
+	if denom == "" {
+		denom = f.denom
+	}
+
+	integerPart := strings.SplitN(amount, ".", 2)[0]
+	if denom != f.denom || f.exponent == 0 {
+		return integerPart + denom
+	}
+
+	number, ok := new(big.Int).SetString(integerPart, 10)
+	if !ok {
+		return amount + denom
+	}
+
+	display, _, _, err := utils.ConvertNumberIntoDisplayWithExponent(number, f.exponent)
+	if err != nil {
+		return integerPart + denom
+	}
+
+	return display + " " + f.symbol
+}
+
+// formatCoins formats the coins, joined by ', '
+func (f bondDenomFormatter) formatCoins(coins []BankBalance) string {
+	if len(coins) == 0 {
+		return "0"
+	}
+	formatted := make([]string, len(coins))
+	for i, coin := range coins {
+		formatted[i] = f.format(coin.Amount, coin.Denom)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatRate formats the decimal rate into percentage, eg: 0.050000000000000000 => 5.00%
+func formatRate(rate string) string {
+	value, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return rate
+	}
+	return fmt.Sprintf("%.2f%%", value*100)
+}
+
+// sumCoins sums the amount of coins by denom, decimal part of DecCoin amount is truncated.
+// The order of denoms is kept as the first appearance.
+func sumCoins(coins []BankBalance) []BankBalance {
+	var denoms []string
+	sums := make(map[string]*big.Int)
+	for _, coin := range coins {
+		amount, ok := new(big.Int).SetString(strings.SplitN(coin.Amount, ".", 2)[0], 10)
+		if !ok {
+			continue
+		}
+		sum, found := sums[coin.Denom]
+		if !found {
+			sum = new(big.Int)
+			sums[coin.Denom] = sum
+			denoms = append(denoms, coin.Denom)
+		}
+		sum.Add(sum, amount)
+	}
+
+	result := make([]BankBalance, len(denoms))
+	for i, denom := range denoms {
+		result[i] = BankBalance{
+			Denom:  denom,
+			Amount: sums[denom].String(),
+		}
+	}
+	return result
+}
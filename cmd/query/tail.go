@@ -0,0 +1,225 @@
+package query
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	acbitypes "github.com/cometbft/cometbft/abci/types"
+	httpclient "github.com/cometbft/cometbft/rpc/client/http"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagQuery = "query"
+)
+
+const tailSubscriber = "devd-tail"
+
+type tailBlockOutput struct {
+	Height int64             `json:"height"`
+	Time   string            `json:"time"`
+	Hash   string            `json:"hash"`
+	NumTxs int               `json:"numTxs"`
+	Events []acbitypes.Event `json:"events,omitempty"`
+}
+
+type tailTxOutput struct {
+	Height  int64             `json:"height"`
+	Index   uint32            `json:"index"`
+	Hash    string            `json:"hash"`
+	EvmHash string            `json:"evmHash,omitempty"`
+	Code    uint32            `json:"code"`
+	Log     string            `json:"log,omitempty"`
+	Events  []acbitypes.Event `json:"events"`
+}
+
+func GetQueryTailCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream new blocks or tx events as they happen, via Tendermint websocket",
+		Long: `Stream new blocks or tx events as they happen, via Tendermint websocket.
+Without --query, new blocks are streamed with all events of the block (same as 'query events [height]').
+With --query, events matching the query are streamed, eg: --query "tm.event='Tx' AND transfer.recipient='ethm1...'".
+--filter has the same semantics as 'query events', blocks/txs without any matching event are skipped.
+Press Ctrl+C to stop.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			query, _ := cmd.Flags().GetString(flagQuery)
+			query = strings.TrimSpace(query)
+			streamBlocks := query == ""
+			if streamBlocks {
+				query = tmtypes.EventQueryNewBlock.String()
+			}
+
+			filters := readEventFilters(cmd)
+			outputFormat := flags.ReadFlagOutputFormat(cmd)
+
+			tendermintRpcHttpClient, _ := flags.MustGetTmRpc(cmd)
+
+			err := tendermintRpcHttpClient.Start()
+			utils.ExitOnErr(err, "failed to start websocket client")
+			defer func() {
+				_ = tendermintRpcHttpClient.Stop()
+			}()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			subscribeCtx, cancelSubscribe := context.WithTimeout(ctx, 10*time.Second)
+			eventsChan, err := tendermintRpcHttpClient.Subscribe(subscribeCtx, tailSubscriber, query, 100)
+			cancelSubscribe()
+			utils.ExitOnErr(err, "failed to subscribe")
+			defer func() {
+				unsubscribeCtx, cancelUnsubscribe := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancelUnsubscribe()
+				_ = tendermintRpcHttpClient.UnsubscribeAll(unsubscribeCtx, tailSubscriber)
+			}()
+
+			utils.PrintlnStdErr("INF: subscribed to", query)
+
+			for {
+				select {
+				case <-ctx.Done():
+					utils.PrintlnStdErr("INF: stopped")
+					return
+				case resultEvent, ok := <-eventsChan:
+					if !ok {
+						utils.PrintlnStdErr("ERR: subscription closed")
+						return
+					}
+
+					switch data := resultEvent.Data.(type) {
+					case tmtypes.EventDataNewBlock:
+						printTailBlock(tendermintRpcHttpClient, data, streamBlocks, filters, outputFormat)
+					case tmtypes.EventDataTx:
+						printTailTx(data, filters, outputFormat)
+					default:
+						utils.PrintOutput(outputFormat, resultEvent, func() {
+							fmt.Printf("%T: %v\n", data, data)
+						})
+					}
+				}
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagTendermintRpc, "", flags.FlagTmRpcDesc)
+	cmd.Flags().String(flagQuery, "", "Tendermint event query to subscribe, default is new blocks")
+	cmd.Flags().StringSliceP(flagFilter, "f", []string{}, "filter events, output only events which contains the filter string. If multiple filters are provided, events that contain one of the filters will be output.")
+
+	return cmd
+}
+
+// printTailBlock prints the new block. If withTxEvents is true, events of txs in the block are fetched via block results.
+func printTailBlock(tendermintRpcHttpClient *httpclient.HTTP, data tmtypes.EventDataNewBlock, withTxEvents bool, filters []string, outputFormat utils.OutputFormat) {
+	block := data.Block
+	if block == nil {
+		return
+	}
+
+	var events []acbitypes.Event
+	events = append(events, data.ResultBeginBlock.Events...)
+	if withTxEvents && len(block.Txs) > 0 {
+		height := block.Height
+		resBlockResults, err := tendermintRpcHttpClient.BlockResults(context.Background(), &height)
+		if err != nil {
+			utils.PrintlnStdErr("WARN: failed to get block results of", height, ":", err)
+		} else {
+			for _, txResult := range resBlockResults.TxsResults {
+				events = append(events, txResult.Events...)
+			}
+		}
+	}
+	events = append(events, data.ResultEndBlock.Events...)
+
+	events = utils.ResolveBase64Events(events)
+	if len(filters) > 0 {
+		events = filterEvents(events, filters)
+		if len(events) < 1 {
+			return
+		}
+	}
+
+	output := tailBlockOutput{
+		Height: block.Height,
+		Time:   block.Time.UTC().Format(time.RFC3339Nano),
+		Hash:   strings.ToUpper(hex.EncodeToString(block.Hash())),
+		NumTxs: len(block.Txs),
+		Events: events,
+	}
+
+	utils.PrintOutput(outputFormat, output, func() {
+		fmt.Printf("Block %d | %s | %d txs | %s\n", output.Height, output.Time, output.NumTxs, output.Hash)
+		if len(filters) > 0 {
+			printTailEvents(output.Events)
+		}
+	})
+}
+
+func printTailTx(data tmtypes.EventDataTx, filters []string, outputFormat utils.OutputFormat) {
+	events := utils.ResolveBase64Events(data.Result.Events)
+
+	var evmHash string
+L1:
+	for _, event := range events {
+		if event.Type != "ethereum_tx" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "ethereumTxHash" {
+				evmHash = attr.Value
+				break L1
+			}
+		}
+	}
+
+	if len(filters) > 0 {
+		events = filterEvents(events, filters)
+		if len(events) < 1 {
+			return
+		}
+	}
+
+	output := tailTxOutput{
+		Height:  data.Height,
+		Index:   data.Index,
+		Hash:    strings.ToUpper(hex.EncodeToString(tmtypes.Tx(data.Tx).Hash())),
+		EvmHash: evmHash,
+		Code:    data.Result.Code,
+		Events:  events,
+	}
+	if output.Code != 0 {
+		output.Log = data.Result.Log
+	}
+
+	utils.PrintOutput(outputFormat, output, func() {
+		fmt.Printf("Tx %s | height %d index %d | code %d", output.Hash, output.Height, output.Index, output.Code)
+		if output.EvmHash != "" {
+			fmt.Printf(" | EVM %s", output.EvmHash)
+		}
+		fmt.Println()
+		if output.Log != "" {
+			fmt.Println("  log:", output.Log)
+		}
+		printTailEvents(output.Events)
+	})
+}
+
+func printTailEvents(events []acbitypes.Event) {
+	for _, event := range events {
+		attrs := make([]string, 0, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs = append(attrs, attr.Key+"="+attr.Value)
+		}
+		fmt.Printf("  %s: %s\n", event.Type, strings.Join(attrs, ", "))
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/bcdevtools/devd/v3/cmd/flags"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
+	acbitypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/spf13/cobra"
 )
 
@@ -45,24 +45,33 @@ func GetQueryTxsInBlockCommand() *cobra.Command {
 				return input
 			}
 
-			tryExtractMsgTypeFromRawTx := func(input []byte) []string {
-				matches := regexp.MustCompile(`/[a-z\d]+(\.[a-z\d]+)+\.Msg[a-zA-Z\d]+`).FindAll(input, -1)
-				var result []string
-				for _, match := range matches {
-					result = append(result, string(match))
-				}
-				return result
-			}
-
+			txs := make([]txInBlockOutput, 0, len(resBlock.Block.Txs))
 			for i, tx := range resBlock.Block.Txs {
-				if i > 0 {
-					utils.PrintlnStdErr("====================================")
-				}
 				txResult := resBlockResult.TxsResults[i]
 				txResult.Events = utils.ResolveBase64Events(txResult.Events)
-				fmt.Println("Index:", i)
-				fmt.Println("Hash:", strings.ToUpper(hex.EncodeToString(tx.Hash())))
-				if strings.Contains(string(tx), "/ethermint.evm.v1.MsgEthereumTx") {
+
+				var msgTypes []string
+				var hasMsgEthereumTx bool
+				if decodedTx, err := utils.DecodeCosmosTx(tx); err == nil {
+					msgTypes = decodedTx.MessageTypes()
+					hasMsgEthereumTx = decodedTx.HasMsgEthereumTx()
+				}
+
+				output := txInBlockOutput{
+					Index:     i,
+					Hash:      strings.ToUpper(hex.EncodeToString(tx.Hash())),
+					Types:     msgTypes,
+					Code:      txResult.Code,
+					GasUsed:   txResult.GasUsed,
+					GasWanted: txResult.GasWanted,
+					Data:      strings.ReplaceAll(string(txResult.Data), "\n", ""),
+					Log:       txResult.Log,
+					Events:    txResult.Events,
+					Info:      txResult.Info,
+					Codespace: txResult.Codespace,
+				}
+
+				if hasMsgEthereumTx {
 				L1:
 					for _, event := range txResult.Events {
 						if event.Type != "ethereum_tx" {
@@ -71,33 +80,48 @@ func GetQueryTxsInBlockCommand() *cobra.Command {
 
 						for _, attr := range event.Attributes {
 							if string(attr.Key) == "ethereumTxHash" {
-								fmt.Println("EvmHash:", attr.Value)
+								output.EvmHash = attr.Value
 								break L1
 							}
 						}
 					}
 				}
-				fmt.Println("Type:", func() string {
-					if msgsType := tryExtractMsgTypeFromRawTx(tx); len(msgsType) > 0 {
-						return strings.Join(msgsType, ", ")
+
+				txs = append(txs, output)
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), txs, func() {
+				for i, output := range txs {
+					if i > 0 {
+						utils.PrintlnStdErr("====================================")
 					}
-					return "(failed to extract msg type)"
-				}())
-				fmt.Println("Code:", txResult.Code)
-				fmt.Println("Gas:", txResult.GasUsed, "/", txResult.GasWanted)
-				fmt.Println("Data:", strings.ReplaceAll(string(txResult.Data), "\n", ""))
-				fmt.Println("Log:", orTextEmpty(txResult.Log))
-				fmt.Println("Events:", func() string {
-					if len(txResult.Events) == 0 {
-						return orTextEmpty("").(string)
+					fmt.Println("Index:", output.Index)
+					fmt.Println("Hash:", output.Hash)
+					if output.EvmHash != "" {
+						fmt.Println("EvmHash:", output.EvmHash)
 					}
-					bz, err := json.Marshal(txResult.Events)
-					utils.ExitOnErr(err, "failed to marshal events")
-					return string(bz)
-				}())
-				fmt.Println("Info:", orTextEmpty(txResult.Info))
-				fmt.Println("Code-space:", orTextEmpty(txResult.Codespace))
-			}
+					fmt.Println("Type:", func() string {
+						if len(output.Types) > 0 {
+							return strings.Join(output.Types, ", ")
+						}
+						return "(failed to extract msg type)"
+					}())
+					fmt.Println("Code:", output.Code)
+					fmt.Println("Gas:", output.GasUsed, "/", output.GasWanted)
+					fmt.Println("Data:", output.Data)
+					fmt.Println("Log:", orTextEmpty(output.Log))
+					fmt.Println("Events:", func() string {
+						if len(output.Events) == 0 {
+							return orTextEmpty("").(string)
+						}
+						bz, err := json.Marshal(output.Events)
+						utils.ExitOnErr(err, "failed to marshal events")
+						return string(bz)
+					}())
+					fmt.Println("Info:", orTextEmpty(output.Info))
+					fmt.Println("Code-space:", orTextEmpty(output.Codespace))
+				}
+			})
 		},
 	}
 
@@ -105,3 +129,18 @@ func GetQueryTxsInBlockCommand() *cobra.Command {
 
 	return cmd
 }
+
+type txInBlockOutput struct {
+	Index     int               `json:"index"`
+	Hash      string            `json:"hash"`
+	EvmHash   string            `json:"evmHash,omitempty"`
+	Types     []string          `json:"types"`
+	Code      uint32            `json:"code"`
+	GasUsed   int64             `json:"gasUsed"`
+	GasWanted int64             `json:"gasWanted"`
+	Data      string            `json:"data"`
+	Log       string            `json:"log"`
+	Events    []acbitypes.Event `json:"events"`
+	Info      string            `json:"info"`
+	Codespace string            `json:"codespace"`
+}
@@ -0,0 +1,249 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	httpclient "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagWatch        = "watch"
+	flagInterval     = "interval"
+	flagSampleBlocks = "sample-blocks"
+)
+
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Time   string `json:"time"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+type upgradeOutput struct {
+	Name            string `json:"name"`
+	Height          int64  `json:"height"`
+	Info            string `json:"info,omitempty"`
+	CurrentHeight   int64  `json:"currentHeight"`
+	RemainingBlocks int64  `json:"remainingBlocks"`
+	AvgBlockTime    string `json:"avgBlockTime"`
+	Eta             string `json:"eta"`
+	EtaIn           string `json:"etaIn"`
+}
+
+func GetQueryUpgradeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Get the current upgrade plan and estimate ETA of the upgrade height",
+		Long: `Get the current upgrade plan and estimate ETA of the upgrade height, based on block time of recent blocks.
+Use --watch to print countdown until the chain halts at the upgrade height.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			watch := cmd.Flags().Changed(flagWatch)
+
+			interval, err := cmd.Flags().GetDuration(flagInterval)
+			utils.ExitOnErr(err, "failed to read interval")
+			if interval < time.Second {
+				utils.PrintlnStdErr("ERR: interval must be at least 1s")
+				os.Exit(1)
+			}
+
+			sampleBlocks, err := cmd.Flags().GetInt64(flagSampleBlocks)
+			utils.ExitOnErr(err, "failed to read sample blocks")
+			if sampleBlocks < 1 {
+				utils.PrintlnStdErr("ERR: sample blocks must be positive")
+				os.Exit(1)
+			}
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+			tendermintRpcHttpClient, _ := flags.MustGetTmRpc(cmd)
+
+			plan, statusCode, err := fetchUpgradeCurrentPlanFromRest(restApiEndpoint)
+			if err != nil {
+				if statusCode == http.StatusNotImplemented {
+					utils.PrintlnStdErr("ERR: REST API does not support query upgrade plan")
+				} else {
+					utils.PrintlnStdErr("ERR: failed to fetch upgrade plan:", err)
+				}
+				os.Exit(1)
+			}
+
+			if plan == nil {
+				utils.PrintlnStdErr("INF: no upgrade plan")
+				return
+			}
+
+			upgradeHeight, err := strconv.ParseInt(plan.Height, 10, 64)
+			if err != nil || upgradeHeight < 1 {
+				utils.PrintlnStdErr("ERR: upgrade plan", plan.Name, "does not have a valid height:", plan.Height)
+				os.Exit(1)
+			}
+
+			avgBlockTime, currentHeight, currentBlockTime := mustEstimateBlockTime(tendermintRpcHttpClient, sampleBlocks)
+			output := computeUpgradeEta(*plan, upgradeHeight, currentHeight, currentBlockTime, avgBlockTime)
+
+			if !watch {
+				utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+					printRow := func(key, value string) {
+						if value == "" {
+							return
+						}
+						fmt.Printf("%-17s: %s\n", key, value)
+					}
+
+					printRow("Name", output.Name)
+					printRow("Height", strconv.FormatInt(output.Height, 10))
+					printRow("Info", output.Info)
+					printRow("Current height", strconv.FormatInt(output.CurrentHeight, 10))
+					printRow("Remaining blocks", strconv.FormatInt(output.RemainingBlocks, 10))
+					printRow("Avg block time", output.AvgBlockTime)
+					printRow("ETA", output.Eta)
+					printRow("ETA in", output.EtaIn)
+				})
+				return
+			}
+
+			watchUpgrade(tendermintRpcHttpClient, *plan, upgradeHeight, avgBlockTime, interval)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+	cmd.Flags().String(flags.FlagTendermintRpc, "", flags.FlagTmRpcDesc)
+	cmd.Flags().Bool(flagWatch, false, "print countdown and exit when the chain halts at the upgrade height")
+	cmd.Flags().Duration(flagInterval, 5*time.Second, "interval between checks in watch mode")
+	cmd.Flags().Int64(flagSampleBlocks, 100, "number of recent blocks used to compute the average block time")
+
+	return cmd
+}
+
+// watchUpgrade prints countdown until the chain halts at the upgrade height.
+// Chain is considered halted when the latest height reached the block before upgrade height and no new block produced for a while,
+// or the node is no longer reachable after that.
+func watchUpgrade(tendermintRpcHttpClient *httpclient.HTTP, plan UpgradePlan, upgradeHeight int64, avgBlockTime time.Duration, interval time.Duration) {
+	haltTimeout := 5 * avgBlockTime
+	if haltTimeout < 30*time.Second {
+		haltTimeout = 30 * time.Second
+	}
+
+	var lastHeight int64
+	var lastHeightChangedAt time.Time
+	var reachedHaltHeight bool
+
+	for {
+		resStatus, err := tendermintRpcHttpClient.Status(context.Background())
+		if err != nil {
+			if reachedHaltHeight {
+				utils.PrintlnStdErr("INF: node is no longer reachable after reaching the upgrade height, chain halted for upgrade", plan.Name)
+				return
+			}
+			utils.PrintlnStdErr("WARN: failed to get node status:", err)
+			time.Sleep(interval)
+			continue
+		}
+
+		currentHeight := resStatus.SyncInfo.LatestBlockHeight
+		if currentHeight != lastHeight {
+			lastHeight = currentHeight
+			lastHeightChangedAt = time.Now()
+		}
+
+		if currentHeight >= upgradeHeight {
+			utils.PrintlnStdErr("INF: chain passed the upgrade height", upgradeHeight, "of upgrade", plan.Name, ", current height", currentHeight)
+			return
+		}
+
+		reachedHaltHeight = currentHeight >= upgradeHeight-1
+		if reachedHaltHeight && time.Since(lastHeightChangedAt) >= haltTimeout {
+			fmt.Printf("Chain halted at height %d for upgrade %s\n", currentHeight, plan.Name)
+			return
+		}
+
+		output := computeUpgradeEta(plan, upgradeHeight, currentHeight, resStatus.SyncInfo.LatestBlockTime, avgBlockTime)
+		if reachedHaltHeight {
+			fmt.Printf("Height %d / %d, waiting for the chain to halt for upgrade %s (no new block for %s)\n", currentHeight, upgradeHeight, plan.Name, time.Since(lastHeightChangedAt).Truncate(time.Second))
+		} else {
+			fmt.Printf("Height %d / %d, %d blocks remaining, ETA %s (in %s)\n", currentHeight, upgradeHeight, output.RemainingBlocks, output.Eta, output.EtaIn)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// mustEstimateBlockTime computes the average block time of recent blocks.
+func mustEstimateBlockTime(tendermintRpcHttpClient *httpclient.HTTP, sampleBlocks int64) (avgBlockTime time.Duration, currentHeight int64, currentBlockTime time.Time) {
+	resStatus, err := tendermintRpcHttpClient.Status(context.Background())
+	utils.ExitOnErr(err, "failed to get node status")
+
+	currentHeight = resStatus.SyncInfo.LatestBlockHeight
+	currentBlockTime = resStatus.SyncInfo.LatestBlockTime
+
+	sampleHeight := currentHeight - sampleBlocks
+	if earliest := resStatus.SyncInfo.EarliestBlockHeight; sampleHeight < earliest {
+		sampleHeight = earliest
+	}
+	if sampleHeight < 1 {
+		sampleHeight = 1
+	}
+	if sampleHeight >= currentHeight {
+		utils.PrintlnStdErr("ERR: not enough blocks to estimate block time")
+		os.Exit(1)
+	}
+
+	resBlockchainInfo, err := tendermintRpcHttpClient.BlockchainInfo(context.Background(), sampleHeight, sampleHeight)
+	utils.ExitOnErr(err, "failed to get block header")
+	if len(resBlockchainInfo.BlockMetas) < 1 {
+		utils.PrintlnStdErr("ERR: block header not found at height", sampleHeight)
+		os.Exit(1)
+	}
+
+	sampleBlockTime := resBlockchainInfo.BlockMetas[0].Header.Time
+	avgBlockTime = currentBlockTime.Sub(sampleBlockTime) / time.Duration(currentHeight-sampleHeight)
+	return
+}
+
+func computeUpgradeEta(plan UpgradePlan, upgradeHeight, currentHeight int64, currentBlockTime time.Time, avgBlockTime time.Duration) upgradeOutput {
+	remainingBlocks := upgradeHeight - currentHeight
+	if remainingBlocks < 0 {
+		remainingBlocks = 0
+	}
+
+	eta := currentBlockTime.Add(time.Duration(remainingBlocks) * avgBlockTime)
+
+	return upgradeOutput{
+		Name:            plan.Name,
+		Height:          upgradeHeight,
+		Info:            plan.Info,
+		CurrentHeight:   currentHeight,
+		RemainingBlocks: remainingBlocks,
+		AvgBlockTime:    avgBlockTime.Round(time.Millisecond).String(),
+		Eta:             eta.Local().Format(time.RFC3339),
+		EtaIn:           time.Until(eta).Round(time.Second).String(),
+	}
+}
+
+// fetchUpgradeCurrentPlanFromRest returns the current upgrade plan, nil if there is no plan.
+func fetchUpgradeCurrentPlanFromRest(rest string) (plan *UpgradePlan, statusCode int, err error) {
+	var response struct {
+		Plan json.RawMessage `json:"plan"`
+	}
+	statusCode, err = fetchJsonFromRest(rest, "/cosmos/upgrade/v1beta1/current_plan", "upgrade plan", &response)
+	if err != nil {
+		return
+	}
+
+	if len(response.Plan) == 0 || string(response.Plan) == "null" {
+		return
+	}
+
+	plan = &UpgradePlan{}
+	err = json.Unmarshal(response.Plan, plan)
+	return
+}
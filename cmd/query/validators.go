@@ -0,0 +1,211 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/spf13/cobra"
+)
+
+type validatorOutput struct {
+	Moniker           string `json:"moniker"`
+	OperatorAddress   string `json:"operatorAddress"`
+	ValconsAddress    string `json:"valconsAddress,omitempty"`
+	Tokens            string `json:"tokens"`
+	TokensRaw         string `json:"tokensRaw"`
+	Status            string `json:"status"`
+	Jailed            bool   `json:"jailed"`
+	Commission        string `json:"commission"`
+	MaxCommission     string `json:"maxCommission"`
+	MaxChangeRate     string `json:"maxChangeRate"`
+	MinSelfDelegation string `json:"minSelfDelegation"`
+	Identity          string `json:"identity,omitempty"`
+	Website           string `json:"website,omitempty"`
+	SecurityContact   string `json:"securityContact,omitempty"`
+	Details           string `json:"details,omitempty"`
+	UnbondingHeight   string `json:"unbondingHeight,omitempty"`
+	UnbondingTime     string `json:"unbondingTime,omitempty"`
+}
+
+func GetQueryValidatorsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validators",
+		Aliases: []string{"vals"},
+		Short:   "Get all validators, sorted by voting power",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			validators := mustFetchStakingValidators(restApiEndpoint)
+			formatter := newBondDenomFormatter(restApiEndpoint)
+
+			outputs := make([]validatorOutput, len(validators))
+			for i, validator := range validators {
+				outputs[i] = toValidatorOutput(validator, formatter)
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), outputs, func() {
+				printRow := func(colMoniker, colOperator, colValcons, colTokens, colStatus, colCommission, colJailed string) {
+					fmt.Printf("%-20s | %-52s | %-52s | %28s | %-9s | %10s | %-6s\n", colMoniker, colOperator, colValcons, colTokens, colStatus, colCommission, colJailed)
+				}
+
+				printRow("Moniker", "Operator", "Valcons", "Tokens", "Status", "Commission", "Jailed")
+
+				for _, output := range outputs {
+					valcons := output.ValconsAddress
+					if valcons == "" {
+						valcons = "-"
+					}
+					printRow(truncateMoniker(output.Moniker), output.OperatorAddress, valcons, output.Tokens, output.Status, output.Commission, fmt.Sprintf("%t", output.Jailed))
+				}
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
+
+func GetQueryValidatorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validator [valoper/0xAccount/Bech32/valcons/moniker]",
+		Aliases: []string{"val"},
+		Short:   "Get validator details",
+		Long: `Get validator details.
+The validator can be provided as the operator address, the account address of the operator (0x or bech32),
+the consensus address or the moniker (case-insensitive).`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			input := strings.TrimSpace(args[0])
+
+			restApiEndpoint := flags.MustGetCosmosRest(cmd)
+
+			validators := mustFetchStakingValidators(restApiEndpoint)
+			validator, found := findValidator(validators, input)
+			if !found {
+				utils.PrintlnStdErr("ERR: validator not found:", input)
+				os.Exit(1)
+			}
+
+			output := toValidatorOutput(validator, newBondDenomFormatter(restApiEndpoint))
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				printRow := func(key, value string) {
+					if value == "" {
+						return
+					}
+					fmt.Printf("%-20s: %s\n", key, value)
+				}
+
+				printRow("Moniker", output.Moniker)
+				printRow("Operator", output.OperatorAddress)
+				printRow("Valcons", output.ValconsAddress)
+				printRow("Tokens", output.Tokens)
+				printRow("Tokens (raw)", output.TokensRaw)
+				printRow("Status", output.Status)
+				printRow("Jailed", fmt.Sprintf("%t", output.Jailed))
+				printRow("Commission", output.Commission)
+				printRow("Max commission", output.MaxCommission)
+				printRow("Max change rate", output.MaxChangeRate)
+				printRow("Min self delegation", output.MinSelfDelegation)
+				printRow("Identity", output.Identity)
+				printRow("Website", output.Website)
+				printRow("Security contact", output.SecurityContact)
+				printRow("Details", output.Details)
+				printRow("Unbonding height", output.UnbondingHeight)
+				printRow("Unbonding time", output.UnbondingTime)
+			})
+		},
+	}
+
+	cmd.Flags().String(flags.FlagCosmosRest, "", flags.FlagCosmosRestDesc)
+
+	return cmd
+}
+
+func toValidatorOutput(validator StakingValidator, formatter bondDenomFormatter) validatorOutput {
+	valcons, err := validator.ValconsAddress()
+	if err != nil {
+		utils.PrintlnStdErr("WARN: failed to compute consensus address of", validator.OperatorAddress, ":", err)
+	}
+
+	output := validatorOutput{
+		Moniker:           validator.Description.Moniker,
+		OperatorAddress:   validator.OperatorAddress,
+		ValconsAddress:    valcons,
+		Tokens:            formatter.format(validator.Tokens, ""),
+		TokensRaw:         validator.Tokens,
+		Status:            validator.ShortStatus(),
+		Jailed:            validator.Jailed,
+		Commission:        formatRate(validator.Commission.CommissionRates.Rate),
+		MaxCommission:     formatRate(validator.Commission.CommissionRates.MaxRate),
+		MaxChangeRate:     formatRate(validator.Commission.CommissionRates.MaxChangeRate),
+		MinSelfDelegation: validator.MinSelfDelegation,
+		Identity:          validator.Description.Identity,
+		Website:           validator.Description.Website,
+		SecurityContact:   validator.Description.SecurityContact,
+		Details:           validator.Description.Details,
+	}
+
+	if validator.UnbondingHeight != "" && validator.UnbondingHeight != "0" {
+		output.UnbondingHeight = validator.UnbondingHeight
+		output.UnbondingTime = validator.UnbondingTime
+	}
+
+	return output
+}
+
+// findValidator finds the validator by operator address, account address of the operator (0x or bech32),
+// consensus address or moniker (case-insensitive).
+func findValidator(validators []StakingValidator, input string) (StakingValidator, bool) {
+	var addrBytes []byte
+	if regexp.MustCompile(`^0x[a-fA-F\d]{40}$`).MatchString(input) {
+		evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(input)
+		if err == nil {
+			addrBytes = evmAddrs[0].Bytes()
+		}
+	} else if _, bz, err := bech32.DecodeAndConvert(strings.ToLower(input)); err == nil {
+		addrBytes = bz
+	}
+
+	for _, validator := range validators {
+		if strings.EqualFold(validator.OperatorAddress, input) {
+			return validator, true
+		}
+
+		if len(addrBytes) > 0 {
+			if _, operatorBytes, err := bech32.DecodeAndConvert(validator.OperatorAddress); err == nil && bytes.Equal(operatorBytes, addrBytes) {
+				return validator, true
+			}
+
+			if valcons, err := validator.ValconsAddress(); err == nil && strings.EqualFold(valcons, input) {
+				return validator, true
+			}
+		}
+	}
+
+	for _, validator := range validators {
+		if strings.EqualFold(strings.TrimSpace(validator.Description.Moniker), input) {
+			return validator, true
+		}
+	}
+
+	return StakingValidator{}, false
+}
+
+// truncateMoniker truncates the moniker to fit the table column.
+func truncateMoniker(moniker string) string {
+	const maxLength = 20
+	runes := []rune(moniker)
+	if len(runes) <= maxLength {
+		return moniker
+	}
+	return string(runes[:maxLength-3]) + "..."
+}
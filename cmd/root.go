@@ -5,12 +5,17 @@ import (
 	"os"
 
 	"github.com/bcdevtools/devd/v3/cmd/check"
+	"github.com/bcdevtools/devd/v3/cmd/config"
 	"github.com/bcdevtools/devd/v3/cmd/convert"
 	"github.com/bcdevtools/devd/v3/cmd/debug"
+	"github.com/bcdevtools/devd/v3/cmd/flags"
 	"github.com/bcdevtools/devd/v3/cmd/hash"
+	"github.com/bcdevtools/devd/v3/cmd/keys"
 	"github.com/bcdevtools/devd/v3/cmd/query"
+	"github.com/bcdevtools/devd/v3/cmd/sign"
 	"github.com/bcdevtools/devd/v3/cmd/tx"
 	"github.com/bcdevtools/devd/v3/cmd/types"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/bcdevtools/devd/v3/constants"
 	"github.com/spf13/cobra"
 )
@@ -42,6 +47,12 @@ func init() {
 	rootCmd.AddCommand(hash.Commands())
 	rootCmd.AddCommand(check.Commands())
 	rootCmd.AddCommand(tx.Commands())
+	rootCmd.AddCommand(config.Commands())
+	rootCmd.AddCommand(keys.Commands())
+	rootCmd.AddCommand(sign.Commands())
+	rootCmd.AddCommand(sign.GetVerifyCommand())
 
 	rootCmd.PersistentFlags().Bool("help", false, "show help")
+	rootCmd.PersistentFlags().String(flags.FlagNetwork, "", flags.FlagNetworkDesc)
+	rootCmd.PersistentFlags().StringP(flags.FlagOutput, "o", string(utils.OutputFormatTable), flags.FlagOutputDesc)
 }
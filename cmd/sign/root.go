@@ -0,0 +1,20 @@
+package sign
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Commands registers a sub-tree of commands
+func Commands() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign messages using EVM account, verify using 'verify' command",
+	}
+
+	cmd.AddCommand(
+		GetSignMessageCommand(),
+		GetSignTypedDataCommand(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+package sign
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagHex = "hex"
+)
+
+type signatureOutput struct {
+	Signer    string `json:"signer"`
+	Hash      string `json:"hash"`
+	R         string `json:"r"`
+	S         string `json:"s"`
+	V         uint8  `json:"v"`
+	Signature string `json:"signature"`
+	Compact   string `json:"compact"`
+}
+
+func GetSignMessageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message [text]",
+		Short: "Sign a message like personal_sign (EIP-191)",
+		Long: `Sign a message like personal_sign (EIP-191), the message is prefixed by "\x19Ethereum Signed Message:\n" + length before hashing.
+Use flag --hex to provide the message as hex bytes.
+Support pipe.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			args, err := utils.ProvidedArgsOrFromPipe(args)
+			utils.ExitOnErr(err, "failed to get args from pipe")
+			utils.RequireArgs(args, cmd)
+
+			message := mustReadMessage(cmd, strings.Join(args, " "))
+
+			mustSignAndPrint(cmd, utils.HashPersonalMessage(message))
+		},
+	}
+
+	cmd.Flags().Bool(flagHex, false, "message is hex encoded bytes")
+	flags.AddSecretEvmAccountFlags(cmd)
+
+	return cmd
+}
+
+func GetSignTypedDataCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "typed-data [file | JSON]",
+		Short: "Sign EIP-712 typed data like eth_signTypedData_v4",
+		Long: `Sign EIP-712 typed data like eth_signTypedData_v4.
+The typed data JSON contains 'types', 'primaryType', 'domain' and 'message', provided via a file or directly.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			typedData := mustReadTypedData(args[0])
+
+			hash, err := utils.HashTypedData(typedData)
+			utils.ExitOnErr(err, "failed to hash typed data")
+
+			mustSignAndPrint(cmd, hash)
+		},
+	}
+
+	flags.AddSecretEvmAccountFlags(cmd)
+
+	return cmd
+}
+
+func mustSignAndPrint(cmd *cobra.Command, hash []byte) {
+	ecdsaPrivateKey, _, signer := flags.MustSecretEvmAccount(cmd)
+
+	sigBytes, err := crypto.Sign(hash, ecdsaPrivateKey)
+	utils.ExitOnErr(err, "failed to sign")
+
+	sig, err := utils.NewSignatureFromBytes(sigBytes)
+	utils.ExitOnErr(err, "failed to read signature")
+
+	output := signatureOutput{
+		Signer:    signer.Hex(),
+		Hash:      hexutil.Encode(hash),
+		R:         sig.R.Hex(),
+		S:         sig.S.Hex(),
+		V:         sig.V,
+		Signature: hexutil.Encode(sig.Bytes()),
+		Compact:   hexutil.Encode(sig.CompactBytes()),
+	}
+
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+		fmt.Println("Signer:", output.Signer)
+		fmt.Println("Hash:", output.Hash)
+		fmt.Println("R:", output.R)
+		fmt.Println("S:", output.S)
+		fmt.Println("V:", output.V)
+		fmt.Println("Signature:", output.Signature)
+		fmt.Println("Compact (EIP-2098):", output.Compact)
+	})
+}
+
+func mustReadMessage(cmd *cobra.Command, input string) []byte {
+	if isHex, _ := cmd.Flags().GetBool(flagHex); isHex {
+		input = strings.TrimSpace(input)
+		if !strings.HasPrefix(input, "0x") {
+			input = "0x" + input
+		}
+		message, err := hexutil.Decode(input)
+		utils.ExitOnErr(err, "failed to decode hex message")
+		return message
+	}
+
+	return []byte(input)
+}
+
+// isTypedDataInput returns true if the input is a typed data JSON or a file.
+func isTypedDataInput(input string) bool {
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		return true
+	}
+	stat, err := os.Stat(input)
+	return err == nil && !stat.IsDir()
+}
+
+func mustReadTypedData(input string) apitypes.TypedData {
+	var bz []byte
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		bz = []byte(input)
+	} else {
+		var err error
+		bz, err = os.ReadFile(input)
+		utils.ExitOnErr(err, "failed to read typed data file")
+	}
+
+	typedData, err := utils.ParseTypedData(bz)
+	utils.ExitOnErr(err, "failed to parse typed data")
+
+	return typedData
+}
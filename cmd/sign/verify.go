@@ -0,0 +1,96 @@
+package sign
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+type verifyOutput struct {
+	Valid     bool   `json:"valid"`
+	Signer    string `json:"signer"`
+	Expected  string `json:"expected"`
+	Hash      string `json:"hash"`
+	R         string `json:"r"`
+	S         string `json:"s"`
+	V         uint8  `json:"v"`
+	Signature string `json:"signature"`
+	Compact   string `json:"compact"`
+}
+
+func GetVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [signature] [message | typed data file | typed data JSON] [address]",
+		Short: "Verify the signature produced by personal_sign (EIP-191) or eth_signTypedData_v4 (EIP-712)",
+		Long: `Verify the signature produced by personal_sign (EIP-191) or eth_signTypedData_v4 (EIP-712), by recovering the signer.
+Signature can be 65 bytes (V is 0/1 or 27/28) or 64 bytes compact (EIP-2098).
+The second argument is treated as EIP-712 typed data if it is a JSON object or an existing file, otherwise a message.
+Use flag --hex to provide the message as hex bytes.`,
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			sigBytes, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(args[0]), "0x"))
+			utils.ExitOnErr(err, "failed to decode signature")
+
+			sig, err := utils.NewSignatureFromBytes(sigBytes)
+			utils.ExitOnErr(err, "failed to read signature")
+
+			if !common.IsHexAddress(args[2]) {
+				utils.PrintlnStdErr("ERR: invalid address", args[2])
+				os.Exit(1)
+			}
+			expected := common.HexToAddress(args[2])
+
+			var hash []byte
+			if isTypedDataInput(args[1]) {
+				utils.PrintlnStdErr("INF: Verifying EIP-712 typed data signature")
+				hash, err = utils.HashTypedData(mustReadTypedData(args[1]))
+				utils.ExitOnErr(err, "failed to hash typed data")
+			} else {
+				utils.PrintlnStdErr("INF: Verifying personal_sign (EIP-191) message signature")
+				hash = utils.HashPersonalMessage(mustReadMessage(cmd, args[1]))
+			}
+
+			signer, err := utils.RecoverSigner(hash, sig)
+			utils.ExitOnErr(err, "failed to recover signer")
+
+			output := verifyOutput{
+				Valid:     signer == expected,
+				Signer:    signer.Hex(),
+				Expected:  expected.Hex(),
+				Hash:      hexutil.Encode(hash),
+				R:         sig.R.Hex(),
+				S:         sig.S.Hex(),
+				V:         sig.V,
+				Signature: hexutil.Encode(sig.Bytes()),
+				Compact:   hexutil.Encode(sig.CompactBytes()),
+			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				fmt.Println("Valid:", output.Valid)
+				fmt.Println("Recovered signer:", output.Signer)
+				fmt.Println("Expected signer:", output.Expected)
+				fmt.Println("Hash:", output.Hash)
+				fmt.Println("R:", output.R)
+				fmt.Println("S:", output.S)
+				fmt.Println("V:", output.V)
+				fmt.Println("Signature:", output.Signature)
+				fmt.Println("Compact (EIP-2098):", output.Compact)
+			})
+
+			if !output.Valid {
+				utils.PrintlnStdErr("ERR: signature is not signed by", expected.Hex())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().Bool(flagHex, false, "message is hex encoded bytes")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package tx
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+func GetBroadcastEvmTxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast [raw EVM tx hex | file]",
+		Short: "Broadcast a signed raw EVM tx then wait for the receipt",
+		Long: `Broadcast a signed raw EVM tx, like the one produced by tx commands with flag --offline, then wait for the receipt.
+The raw tx can be provided directly as hex or via a file contains the hex.
+Use "debug raw-tx" to review the content of the raw tx before broadcasting.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rawTx := strings.TrimSpace(args[0])
+			if !regexp.MustCompile(`^(0x)?[a-fA-F\d]+$`).MatchString(rawTx) {
+				bz, err := os.ReadFile(rawTx)
+				utils.ExitOnErr(err, "failed to read raw tx file")
+				rawTx = strings.TrimSpace(string(bz))
+			}
+
+			signedTx, err := utils.DecodeRawEvmTx(rawTx)
+			utils.ExitOnErr(err, "failed to decode into EVM tx")
+
+			from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(signedTx.ChainId()), signedTx)
+			utils.ExitOnErr(err, "failed to recover sender of the tx")
+
+			contractAbi, err := flags.ReadFlagAbiOrNil(cmd)
+			utils.ExitOnErr(err, "failed to read ABI file")
+
+			ethClient8545, _ := flags.MustGetEthClient(cmd)
+
+			chainId := mustGetChainId(cmd, ethClient8545)
+			if signedTx.Protected() && signedTx.ChainId().Cmp(chainId) != 0 {
+				utils.PrintlnStdErr("ERR: tx is signed for chain ID", signedTx.ChainId().String(), "but EVM Json-RPC is chain ID", chainId.String())
+				os.Exit(1)
+			}
+
+			utils.PrintlnStdErr("INF: Broadcasting tx", signedTx.Hash(), "from", from.Hex(), "with nonce", signedTx.Nonce())
+
+			err = ethClient8545.SendTransaction(context.Background(), signedTx)
+			utils.ExitOnErr(err, "failed to send tx")
+
+			receipt := waitForEthTxReceipt(ethClient8545, signedTx.Hash())
+			if receipt == nil {
+				utils.PrintlnStdErr("WARN: Timed out waiting for tx to be mined")
+				os.Exit(1)
+			}
+
+			output := txCallOutput{
+				TxHash:      signedTx.Hash().Hex(),
+				Status:      receipt.Status,
+				Success:     receipt.Status == ethtypes.ReceiptStatusSuccessful,
+				BlockNumber: receipt.BlockNumber.String(),
+				GasUsed:     receipt.GasUsed,
+				Events:      decodeReceiptEvents(receipt, contractAbi),
+			}
+
+			printTxReceiptOutput(cmd, output)
+
+			if !output.Success {
+				utils.PrintlnStdErr("ERR: Tx failed")
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc+", used to decode events")
+
+	return cmd
+}
@@ -0,0 +1,262 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagValue = "value"
+)
+
+// builtInEvents are events those always can be decoded without ABI file
+var builtInEvents = []string{
+	"Transfer(address indexed from, address indexed to, uint256 value)",
+	"Approval(address indexed owner, address indexed spender, uint256 value)",
+}
+
+func GetCallContractEvmTxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call [contract address] [method signature | method args..] [?method args..]",
+		Short: "Send a transaction to call a contract method with ABI-encoded arguments",
+		Long: fmt.Sprintf(`Send a transaction to call a contract method with ABI-encoded arguments, then print the receipt status and decoded events.
+The method can be provided in one of the following ways:
+- Method signature, followed by the arguments: tx call 0xContract 'approve(address,uint256)' 0xSpender 1e18
+- ABI file and method name, followed by the arguments: tx call 0xContract 0xSpender 1e18 --%s erc20.json --%s approve
+Arguments: address accepts 0x/bech32, numbers accept short int (1e18, 5m) and hex, arrays and tuples are JSON arrays.
+Events are decoded using the ABI file if provided, ERC-20 Transfer & Approval events are always decoded.`, flags.FlagAbi, flags.FlagMethod),
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ethClient8545 := mustGetEthClientUnlessOffline(cmd)
+
+			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args[0])
+			utils.ExitOnErr(err, "failed to parse contract address")
+			contractAddr := evmAddrs[0]
+
+			method, methodArgs, err := flags.ReadAbiMethodAndArgs(cmd, args[1:])
+			utils.ExitOnErr(err, "failed to resolve method")
+
+			callData, err := utils.AbiPackMethodCall(method, methodArgs)
+			utils.ExitOnErr(err, "failed to encode call data")
+
+			value, err := flags.ReadFlagShortIntOrHexOrNil(cmd, flagValue)
+			utils.ExitOnErr(err, "failed to parse value")
+			if value == nil {
+				value = big.NewInt(0)
+			}
+
+			contractAbi, err := flags.ReadFlagAbiOrNil(cmd)
+			utils.ExitOnErr(err, "failed to read ABI file")
+
+			ecdsaPrivateKey, _, from := flags.MustSecretEvmAccount(cmd)
+
+			nonce := mustGetNonce(cmd, ethClient8545, *from)
+
+			chainId := mustGetChainId(cmd, ethClient8545)
+
+			txFee := mustReadEvmTxFee(cmd, ethClient8545, 20_000_000_000)
+
+			gasLimit := mustReadGasLimitOrEstimate(cmd, ethClient8545, txFee.callMsg(*from, &contractAddr, value, callData))
+
+			tx := txFee.newTx(chainId, nonce, &contractAddr, value, gasLimit, callData)
+
+			utils.PrintlnStdErr("INF: Calling", method.Sig, "of contract", contractAddr.Hex(), "from", from.Hex())
+			utils.PrintlnStdErr("INF: EIP155 Chain ID:", chainId.String(), "and nonce", nonce)
+
+			signedTx, err := ethtypes.SignTx(tx, ethtypes.LatestSignerForChainID(chainId), ecdsaPrivateKey)
+			utils.ExitOnErr(err, "failed to sign tx")
+
+			utils.PrintlnStdErr("INF: Tx hash", signedTx.Hash())
+
+			if ethClient8545 == nil {
+				mustPrintOfflineSignedTx(cmd, signedTx, nil)
+				return
+			}
+
+			if cmd.Flags().Changed(flagRawTx) {
+				printRawEvmTx(signedTx)
+			}
+
+			err = ethClient8545.SendTransaction(context.Background(), signedTx)
+			utils.ExitOnErr(err, "failed to send tx")
+
+			receipt := waitForEthTxReceipt(ethClient8545, signedTx.Hash())
+			if receipt == nil {
+				utils.PrintlnStdErr("WARN: Timed out waiting for tx to be mined")
+				os.Exit(1)
+			}
+
+			output := txCallOutput{
+				TxHash:      signedTx.Hash().Hex(),
+				Status:      receipt.Status,
+				Success:     receipt.Status == ethtypes.ReceiptStatusSuccessful,
+				BlockNumber: receipt.BlockNumber.String(),
+				GasUsed:     receipt.GasUsed,
+				Method:      method.Sig,
+				Events:      decodeReceiptEvents(receipt, contractAbi),
+			}
+
+			printTxReceiptOutput(cmd, output)
+
+			if !output.Success {
+				utils.PrintlnStdErr("ERR: Tx failed")
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
+	flags.AddSecretEvmAccountFlags(cmd)
+	addEvmTxGasFlags(cmd, flagGasLimitDesc)
+	cmd.Flags().String(flags.FlagGasPrices, "20b", flagGasPricesDesc)
+	addEvmTxTypeFlags(cmd)
+	cmd.Flags().String(flagValue, "", "value sent with this transaction, support short int and hex")
+	cmd.Flags().String(flags.FlagAbi, "", flags.FlagAbiDesc)
+	cmd.Flags().String(flags.FlagMethod, "", flags.FlagMethodDesc)
+	addEvmTxOfflineFlags(cmd)
+	cmd.Flags().Bool(flagRawTx, false, flagRawTxDesc)
+
+	return cmd
+}
+
+// printTxReceiptOutput prints the receipt summary and the decoded events of the tx.
+func printTxReceiptOutput(cmd *cobra.Command, output txCallOutput) {
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+		fmt.Println("Tx hash:", output.TxHash)
+		fmt.Println("Block:", output.BlockNumber)
+		fmt.Println("Status:", output.Status, func() string {
+			if output.Success {
+				return "(success)"
+			}
+			return "(failed)"
+		}())
+		fmt.Println("Gas used:", output.GasUsed)
+		fmt.Println("Events:", len(output.Events))
+		for _, event := range output.Events {
+			if event.Event == "" {
+				fmt.Printf(" - [%d] %s: %s\n", event.LogIndex, event.Address, event.Topic0)
+				continue
+			}
+			fmt.Printf(" - [%d] %s: %s\n", event.LogIndex, event.Address, event.Event)
+			names := make([]string, 0, len(event.Args))
+			for name := range event.Args {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("   + %s: %v\n", name, event.Args[name])
+			}
+		}
+	})
+}
+
+type txCallOutput struct {
+	TxHash      string              `json:"txHash"`
+	Status      uint64              `json:"status"`
+	Success     bool                `json:"success"`
+	BlockNumber string              `json:"blockNumber"`
+	GasUsed     uint64              `json:"gasUsed"`
+	Method      string              `json:"method,omitempty"`
+	Events      []txCallEventOutput `json:"events"`
+}
+
+type txCallEventOutput struct {
+	LogIndex uint           `json:"logIndex"`
+	Address  string         `json:"address"`
+	Topic0   string         `json:"topic0,omitempty"`
+	Event    string         `json:"event,omitempty"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// decodeReceiptEvents decodes the logs of the receipt using events of the ABI and the built-in events.
+// Logs those cannot be decoded will be output with the first topic only.
+func decodeReceiptEvents(receipt *ethtypes.Receipt, contractAbi *abi.ABI) []txCallEventOutput {
+	knownEvents := make(map[common.Hash]abi.Event)
+	if contractAbi != nil {
+		for _, event := range contractAbi.Events {
+			knownEvents[event.ID] = event
+		}
+	}
+	knownBuiltInEvents := make(map[common.Hash]abi.Event)
+	for _, signature := range builtInEvents {
+		event, err := utils.ParseEventSignature(signature)
+		utils.ExitOnErr(err, "failed to parse built-in event")
+		knownBuiltInEvents[event.ID] = event
+	}
+
+	events := make([]txCallEventOutput, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		event := txCallEventOutput{
+			LogIndex: log.Index,
+			Address:  log.Address.Hex(),
+		}
+		if len(log.Topics) > 0 {
+			event.Topic0 = log.Topics[0].Hex()
+
+			abiEvent, found := knownEvents[log.Topics[0]]
+			if !found {
+				// built-in events share the signature with events of other standards,
+				// eg: ERC-721 Transfer has the same signature as ERC-20 Transfer but with the token id indexed.
+				abiEvent, found = knownBuiltInEvents[log.Topics[0]]
+				if found && len(log.Topics) != countIndexedInputs(abiEvent)+1 {
+					found = false
+				}
+			}
+
+			if found {
+				decoded, err := utils.DecodeEvmLog(abiEvent, log.Topics, log.Data)
+				if err != nil {
+					utils.PrintlnStdErr("WARN: failed to decode log", log.Index, ":", err)
+				} else {
+					event.Event = abiEvent.Sig
+					event.Args = decoded
+				}
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func countIndexedInputs(event abi.Event) int {
+	var count int
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			count++
+		}
+	}
+	return count
+}
+
+// waitForEthTxReceipt waits for the tx to be mined then returns the receipt, returns nil if timed out.
+func waitForEthTxReceipt(ethClient8545 *ethclient.Client, txHash common.Hash) *ethtypes.Receipt {
+	if tx := waitForEthTx(ethClient8545, txHash); tx == nil {
+		return nil
+	}
+
+	const avgBlockTime = 5 * time.Second
+	startTime := time.Now()
+
+	for time.Since(startTime) < avgBlockTime*3+time.Second {
+		receipt, err := ethClient8545.TransactionReceipt(context.Background(), txHash)
+		if err == nil && receipt != nil {
+			return receipt
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return nil
+}
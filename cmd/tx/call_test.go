@@ -0,0 +1,72 @@
+package tx
+
+import (
+	"io"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeReceiptEvents(t *testing.T) {
+	topicTransfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	contract := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	receipt := &ethtypes.Receipt{
+		Logs: []*ethtypes.Log{
+			{
+				// ERC-20 Transfer
+				Address: contract,
+				Topics: []common.Hash{
+					topicTransfer,
+					common.BytesToHash(from.Bytes()),
+					common.BytesToHash(to.Bytes()),
+				},
+				Data:  common.BigToHash(big.NewInt(1000)).Bytes(),
+				Index: 0,
+			},
+			{
+				// ERC-721 Transfer, token id is indexed
+				Address: contract,
+				Topics: []common.Hash{
+					topicTransfer,
+					common.BytesToHash(from.Bytes()),
+					common.BytesToHash(to.Bytes()),
+					common.BigToHash(big.NewInt(7)),
+				},
+				Index: 1,
+			},
+		},
+	}
+
+	// capture stderr to ensure no warning printed
+	originalStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+	events := decodeReceiptEvents(receipt, nil)
+	os.Stderr = originalStderr
+	require.NoError(t, w.Close())
+	stderr, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Empty(t, string(stderr))
+
+	require.Len(t, events, 2)
+
+	require.Equal(t, "Transfer(address,address,uint256)", events[0].Event)
+	require.Equal(t, map[string]any{
+		"from":  from.Hex(),
+		"to":    to.Hex(),
+		"value": "1000",
+	}, events[0].Args)
+
+	require.Empty(t, events[1].Event, "built-in ERC-20 Transfer must not be used to decode ERC-721 Transfer")
+	require.Nil(t, events[1].Args)
+	require.Equal(t, uint(1), events[1].LogIndex)
+	require.Equal(t, topicTransfer.Hex(), events[1].Topic0)
+}
@@ -1,7 +1,6 @@
 package tx
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -41,30 +40,26 @@ Predefined bytecode: erc20`,
 	}
 
 	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
-	cmd.Flags().String(flags.FlagSecretKey, "", flags.FlagSecretKeyDesc)
-	cmd.Flags().String(flags.FlagGasLimit, "4m", flagGasLimitDesc)
+	flags.AddSecretEvmAccountFlags(cmd)
+	addEvmTxGasFlags(cmd, flagGasLimitDesc)
 	cmd.Flags().String(flags.FlagGasPrices, "20b", flagGasPricesDesc)
+	addEvmTxTypeFlags(cmd)
+	addEvmTxOfflineFlags(cmd)
 	cmd.Flags().Bool(flagRawTx, false, flagRawTxDesc)
 
 	return cmd
 }
 
 func deployEvmContract(bytecode string, cmd *cobra.Command) {
-	ethClient8545, _ := flags.MustGetEthClient(cmd)
+	ethClient8545 := mustGetEthClientUnlessOffline(cmd)
 
 	ecdsaPrivateKey, _, from := flags.MustSecretEvmAccount(cmd)
 
-	gasPrices, err := flags.ReadFlagGasPrices(cmd, flags.FlagGasPrices, 20_000_000_000)
-	utils.ExitOnErr(err, "failed to parse gas price")
+	nonce := mustGetNonce(cmd, ethClient8545, *from)
 
-	gasLimit, err := flags.ReadFlagGasLimit(cmd, flags.FlagGasLimit, 500_000)
-	utils.ExitOnErr(err, "failed to parse gas limit")
+	chainId := mustGetChainId(cmd, ethClient8545)
 
-	nonce, err := ethClient8545.NonceAt(context.Background(), *from, nil)
-	utils.ExitOnErr(err, "failed to get nonce of sender")
-
-	chainId, err := ethClient8545.ChainID(context.Background())
-	utils.ExitOnErr(err, "failed to get chain ID")
+	txFee := mustReadEvmTxFee(cmd, ethClient8545, 20_000_000_000)
 
 	if strings.HasPrefix(bytecode, "0x") {
 		bytecode = bytecode[2:]
@@ -72,15 +67,9 @@ func deployEvmContract(bytecode string, cmd *cobra.Command) {
 	deploymentBytes, err := hex.DecodeString(bytecode)
 	utils.ExitOnErr(err, "failed to parse deployment bytecode")
 
-	txData := ethtypes.LegacyTx{
-		Nonce:    nonce,
-		GasPrice: gasPrices,
-		Gas:      gasLimit,
-		To:       nil,
-		Data:     deploymentBytes,
-		Value:    common.Big0,
-	}
-	tx := ethtypes.NewTx(&txData)
+	gasLimit := mustReadGasLimitOrEstimate(cmd, ethClient8545, txFee.callMsg(*from, nil, common.Big0, deploymentBytes))
+
+	tx := txFee.newTx(chainId, nonce, nil, common.Big0, gasLimit, deploymentBytes)
 
 	newContractAddress := crypto.CreateAddress(*from, nonce)
 
@@ -89,12 +78,14 @@ func deployEvmContract(bytecode string, cmd *cobra.Command) {
 	signedTx, err := ethtypes.SignTx(tx, ethtypes.LatestSignerForChainID(chainId), ecdsaPrivateKey)
 	utils.ExitOnErr(err, "failed to sign tx")
 
-	var buf bytes.Buffer
-	err = signedTx.EncodeRLP(&buf)
-	utils.ExitOnErr(err, "failed to encode tx")
-
 	utils.PrintlnStdErr("INF: Tx hash", signedTx.Hash())
 
+	if ethClient8545 == nil {
+		utils.PrintlnStdErr("INF: Expected contract address:", newContractAddress)
+		mustPrintOfflineSignedTx(cmd, signedTx, &newContractAddress)
+		return
+	}
+
 	if cmd.Flags().Changed(flagRawTx) {
 		printRawEvmTx(signedTx)
 	}
@@ -102,13 +93,27 @@ func deployEvmContract(bytecode string, cmd *cobra.Command) {
 	err = ethClient8545.SendTransaction(context.Background(), signedTx)
 	utils.ExitOnErr(err, "failed to send tx")
 
-	if tx := waitForEthTx(ethClient8545, signedTx.Hash()); tx != nil {
-		utils.PrintlnStdErr("INF: New contract deployed at:")
-	} else {
-		utils.PrintlnStdErr("WARN: Timed-out waiting for tx to be mined, contract may have been deployed.")
-		utils.PrintlnStdErr("INF: Expected contract address:")
+	output := deployContractOutput{
+		TxHash:          signedTx.Hash().Hex(),
+		ContractAddress: newContractAddress.Hex(),
+		Mined:           waitForEthTx(ethClient8545, signedTx.Hash()) != nil,
 	}
-	fmt.Println(newContractAddress)
+
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+		if output.Mined {
+			utils.PrintlnStdErr("INF: New contract deployed at:")
+		} else {
+			utils.PrintlnStdErr("WARN: Timed-out waiting for tx to be mined, contract may have been deployed.")
+			utils.PrintlnStdErr("INF: Expected contract address:")
+		}
+		fmt.Println(output.ContractAddress)
+	})
+}
+
+type deployContractOutput struct {
+	TxHash          string `json:"txHash"`
+	ContractAddress string `json:"contractAddress"`
+	Mined           bool   `json:"mined"`
 }
 
 func waitForEthTx(ethClient8545 *ethclient.Client, txHash common.Hash) *ethtypes.Transaction {
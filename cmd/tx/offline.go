@@ -0,0 +1,107 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagOffline = "offline"
+	flagNonce   = "nonce"
+	flagChainId = "chain-id"
+)
+
+// addEvmTxOfflineFlags registers flags used to sign EVM tx without connecting to the EVM Json-RPC.
+func addEvmTxOfflineFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagOffline, false, fmt.Sprintf("sign the tx without any RPC call then print the raw tx instead of broadcasting, requires --%s, --%s (or network profile) and explicit gas & fees", flagNonce, flagChainId))
+	cmd.Flags().Uint64(flagNonce, 0, "nonce of the sender, default is the nonce from EVM Json-RPC")
+	cmd.Flags().String(flagChainId, "", "EIP-155 chain ID, default is the chain ID from EVM Json-RPC or network profile")
+}
+
+// isOffline returns true if the tx should be signed without connecting to the EVM Json-RPC.
+func isOffline(cmd *cobra.Command) bool {
+	offline, _ := cmd.Flags().GetBool(flagOffline)
+	return offline
+}
+
+// mustGetEthClientUnlessOffline returns the EVM Json-RPC client, or nil in offline mode.
+func mustGetEthClientUnlessOffline(cmd *cobra.Command) *ethclient.Client {
+	if isOffline(cmd) {
+		utils.PrintlnStdErr("INF: Offline mode, the tx will be signed without connecting to EVM Json-RPC")
+		return nil
+	}
+
+	ethClient8545, _ := flags.MustGetEthClient(cmd)
+	return ethClient8545
+}
+
+// mustGetNonce returns the nonce provided via flag, or the nonce of the sender from the EVM Json-RPC.
+func mustGetNonce(cmd *cobra.Command, ethClient8545 *ethclient.Client, from common.Address) uint64 {
+	if cmd.Flags().Changed(flagNonce) {
+		nonce, _ := cmd.Flags().GetUint64(flagNonce)
+		return nonce
+	}
+
+	if ethClient8545 == nil {
+		utils.PrintfStdErr("ERR: flag --%s is required in offline mode\n", flagNonce)
+		os.Exit(1)
+	}
+
+	nonce, err := ethClient8545.NonceAt(context.Background(), from, nil)
+	utils.ExitOnErr(err, "failed to get nonce of sender")
+	return nonce
+}
+
+// mustReadFlagChainIdOrNil reads the chain ID from flag, or from the network profile in use.
+func mustReadFlagChainIdOrNil(cmd *cobra.Command) *big.Int {
+	chainIdStr, _ := cmd.Flags().GetString(flagChainId)
+	if chainIdStr == "" {
+		if profile := flags.GetNetworkProfile(cmd); profile != nil && profile.ChainId != "" {
+			chainIdStr = profile.ChainId
+		}
+	}
+	if chainIdStr == "" {
+		return nil
+	}
+
+	chainId, err := utils.ReadShortIntOrHex(chainIdStr)
+	if err != nil || chainId.Sign() < 1 {
+		utils.PrintfStdErr("ERR: invalid chain ID %s\n", chainIdStr)
+		os.Exit(1)
+	}
+	return chainId
+}
+
+// mustPrintOfflineSignedTx prints the signed raw tx, it can be submitted later using `tx broadcast`.
+// The expected address of the new contract is included when the tx deploys a contract.
+func mustPrintOfflineSignedTx(cmd *cobra.Command, signedTx *ethtypes.Transaction, newContractAddress *common.Address) {
+	utils.PrintlnStdErr("INF: Offline mode, tx is not broadcasted, submit later using `devd tx broadcast`")
+
+	output := offlineSignedTxOutput{
+		TxHash: signedTx.Hash().Hex(),
+		RawTx:  mustEncodeRawEvmTx(signedTx),
+	}
+	if newContractAddress != nil {
+		output.ContractAddress = newContractAddress.Hex()
+	}
+
+	utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+		utils.PrintlnStdErr("INF: Signed raw EVM tx:")
+		fmt.Println(output.RawTx)
+	})
+}
+
+type offlineSignedTxOutput struct {
+	TxHash          string `json:"txHash"`
+	RawTx           string `json:"rawTx"`
+	ContractAddress string `json:"contractAddress,omitempty"`
+}
@@ -1,10 +1,15 @@
 package tx
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
 
+	"github.com/bcdevtools/devd/v3/cmd/flags"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
 	"github.com/spf13/cobra"
@@ -15,7 +20,7 @@ const (
 )
 
 const (
-	flagGasLimitDesc  = "Gas limit for the transaction, support custom unit (eg: 1m equals to one million, 21k equals to thousand)"
+	flagGasLimitDesc  = "Gas limit for the transaction, support custom unit (eg: 1m equals to one million, 21k equals to thousand) or 'auto' to estimate"
 	flagGasPricesDesc = "Gas prices for the transaction, support custom unit (eg: both 20b and 20g(wei) equals to twenty billion)"
 	flagRawTxDesc     = "Print raw tx"
 )
@@ -30,16 +35,61 @@ func Commands() *cobra.Command {
 	cmd.AddCommand(
 		GetSendEvmTxCommand(),
 		GetDeployContractEvmTxCommand(),
+		GetCallContractEvmTxCommand(),
+		GetBroadcastEvmTxCommand(),
 	)
 
 	return cmd
 }
 
+// printRawEvmTx prints the raw tx in the format accepted by `eth_sendRawTransaction`.
 func printRawEvmTx(signedTx *ethtypes.Transaction) {
-	var buf bytes.Buffer
-	err := signedTx.EncodeRLP(&buf)
+	utils.PrintfStdErr("INF: Raw EVM tx: %s\n", mustEncodeRawEvmTx(signedTx))
+}
+
+// mustEncodeRawEvmTx encodes the tx in the format accepted by `eth_sendRawTransaction`,
+// RLP-encoded for legacy tx and EIP-2718 typed envelope for other tx types.
+func mustEncodeRawEvmTx(signedTx *ethtypes.Transaction) string {
+	bz, err := signedTx.MarshalBinary()
 	utils.ExitOnErr(err, "failed to encode tx")
 
-	rawTxRlpEncoded := hex.EncodeToString(buf.Bytes())
-	utils.PrintfStdErr("INF: Raw EVM tx: 0x%s\n", rawTxRlpEncoded)
+	return "0x" + hex.EncodeToString(bz)
+}
+
+// mustGetChainId returns the EVM chain ID provided by the EVM Json-RPC.
+// If the network profile in use or flag --chain-id specifies a chain ID, the program will exit when they are mismatched.
+// In offline mode (nil client), the chain ID from flag or network profile is returned.
+func mustGetChainId(cmd *cobra.Command, ethClient8545 *ethclient.Client) *big.Int {
+	if ethClient8545 == nil {
+		chainId := mustReadFlagChainIdOrNil(cmd)
+		if chainId == nil {
+			utils.PrintfStdErr("ERR: flag --%s or network profile with chain ID is required in offline mode\n", flagChainId)
+			os.Exit(1)
+		}
+		return chainId
+	}
+
+	chainId, err := ethClient8545.ChainID(context.Background())
+	utils.ExitOnErr(err, "failed to get chain ID")
+
+	if profile := flags.GetNetworkProfile(cmd); profile != nil && profile.ChainId != "" {
+		profileChainId, err := utils.ReadShortIntOrHex(profile.ChainId)
+		utils.ExitOnErr(err, fmt.Sprintf("invalid chain ID %s of network profile [%s]", profile.ChainId, profile.Name))
+		if profileChainId.Cmp(chainId) != 0 {
+			utils.PrintfStdErr("ERR: chain ID %s of EVM Json-RPC does not match chain ID %s of network profile [%s]\n", chainId, profile.ChainId, profile.Name)
+			os.Exit(1)
+		}
+		utils.PrintlnStdErr("INF: Chain ID", chainId.String(), fmt.Sprintf("matches network profile %s", profile.Name))
+	}
+
+	if chainIdStr, _ := cmd.Flags().GetString(flagChainId); chainIdStr != "" {
+		flagChainIdValue, err := utils.ReadShortIntOrHex(chainIdStr)
+		utils.ExitOnErr(err, fmt.Sprintf("invalid chain ID %s provided via --%s", chainIdStr, flagChainId))
+		if flagChainIdValue.Cmp(chainId) != 0 {
+			utils.PrintfStdErr("ERR: chain ID %s of EVM Json-RPC does not match chain ID %s provided via --%s\n", chainId, chainIdStr, flagChainId)
+			os.Exit(1)
+		}
+	}
+
+	return chainId
 }
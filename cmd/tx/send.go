@@ -25,13 +25,7 @@ func GetSendEvmTxCommand() *cobra.Command {
 Support short int`,
 		Args: cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
-			ethClient8545, _ := flags.MustGetEthClient(cmd)
-
-			gasPrices, err := flags.ReadFlagGasPrices(cmd, flags.FlagGasPrices, 20_000_000_000)
-			utils.ExitOnErr(err, "failed to parse gas price")
-
-			gasLimit, err := flags.ReadFlagGasLimit(cmd, flags.FlagGasLimit, 500_000)
-			utils.ExitOnErr(err, "failed to parse gas limit")
+			ethClient8545 := mustGetEthClientUnlessOffline(cmd)
 
 			evmAddrs, err := utils.GetEvmAddressFromAnyFormatAddress(args[0])
 			utils.ExitOnErr(err, "failed to get evm address from input")
@@ -58,7 +52,9 @@ Support short int`,
 			}
 
 			var exponent int
-			if pErc20ContractAddress != nil {
+			if pErc20ContractAddress != nil && ethClient8545 == nil {
+				utils.PrintlnStdErr("WARN: decimals of ERC-20 contract is unknown in offline mode, amount is displayed as raw")
+			} else if pErc20ContractAddress != nil {
 				bz, err := ethClient8545.CallContract(context.Background(), ethereum.CallMsg{
 					To:   pErc20ContractAddress,
 					Data: []byte{0x31, 0x3c, 0xe5, 0x67}, // decimals()
@@ -75,48 +71,43 @@ Support short int`,
 
 			ecdsaPrivateKey, _, from := flags.MustSecretEvmAccount(cmd)
 
-			nonce, err := ethClient8545.NonceAt(context.Background(), *from, nil)
-			utils.ExitOnErr(err, "failed to get nonce of sender")
+			nonce := mustGetNonce(cmd, ethClient8545, *from)
+
+			chainId := mustGetChainId(cmd, ethClient8545)
 
-			chainId, err := ethClient8545.ChainID(context.Background())
-			utils.ExitOnErr(err, "failed to get chain ID")
+			txFee := mustReadEvmTxFee(cmd, ethClient8545, 20_000_000_000)
 
-			var txData ethtypes.LegacyTx
+			var tx *ethtypes.Transaction
 			if pErc20ContractAddress != nil {
 				data := []byte{0xa9, 0x05, 0x9c, 0xbb}
 				data = append(data, common.LeftPadBytes(receiverAddr.Bytes(), 32)...)
 				data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
 
-				txData = ethtypes.LegacyTx{
-					Nonce:    nonce,
-					GasPrice: gasPrices,
-					Gas:      gasLimit,
-					To:       pErc20ContractAddress,
-					Value:    big.NewInt(0),
-					Data:     data,
-				}
+				gasLimit := mustReadGasLimitOrEstimate(cmd, ethClient8545, txFee.callMsg(*from, pErc20ContractAddress, big.NewInt(0), data))
+
+				tx = txFee.newTx(chainId, nonce, pErc20ContractAddress, big.NewInt(0), gasLimit, data)
 			} else {
-				if gasLimit > 21000 {
-					utils.PrintfStdErr("WARN: setting gas limit by flag --%s will be ignored, ony use 21,000 gas\n", flags.FlagGasPrices)
-				}
-				txData = ethtypes.LegacyTx{
-					Nonce:    nonce,
-					GasPrice: gasPrices,
-					Gas:      21000,
-					To:       &receiverAddr,
-					Value:    amount,
+				// intrinsic gas of native transfer, plus the cost of access list if any
+				nativeTransferGas := 21000 + intrinsicGasOfAccessList(txFee.accessList)
+				if cmd.Flags().Changed(flags.FlagGasLimit) {
+					utils.PrintfStdErr("WARN: setting gas limit by flag --%s will be ignored, ony use %d gas\n", flags.FlagGasLimit, nativeTransferGas)
 				}
+				tx = txFee.newTx(chainId, nonce, &receiverAddr, amount, nativeTransferGas, nil)
 			}
-			tx := ethtypes.NewTx(&txData)
 
 			utils.PrintlnStdErr("INF: Send", display, "from", from.Hex(), "to", receiverAddr.Hex())
-			utils.PrintlnStdErr("INF: EIP155 Chain ID:", chainId.String(), "and nonce", txData.Nonce)
+			utils.PrintlnStdErr("INF: EIP155 Chain ID:", chainId.String(), "and nonce", nonce)
 
 			signedTx, err := ethtypes.SignTx(tx, ethtypes.LatestSignerForChainID(chainId), ecdsaPrivateKey)
 			utils.ExitOnErr(err, "failed to sign tx")
 
 			utils.PrintlnStdErr("INF: Tx hash", signedTx.Hash())
 
+			if ethClient8545 == nil {
+				mustPrintOfflineSignedTx(cmd, signedTx, nil)
+				return
+			}
+
 			if cmd.Flags().Changed(flagRawTx) {
 				printRawEvmTx(signedTx)
 			}
@@ -124,20 +115,44 @@ Support short int`,
 			err = ethClient8545.SendTransaction(context.Background(), signedTx)
 			utils.ExitOnErr(err, "failed to send tx")
 
-			if tx := waitForEthTx(ethClient8545, signedTx.Hash()); tx != nil {
-				utils.PrintlnStdErr("INF: Tx executed successfully")
-			} else {
-				utils.PrintlnStdErr("WARN: Timed out waiting for tx to be mined")
+			output := sendTxOutput{
+				TxHash: signedTx.Hash().Hex(),
+				From:   from.Hex(),
+				To:     receiverAddr.Hex(),
+				Amount: display,
+				Mined:  waitForEthTx(ethClient8545, signedTx.Hash()) != nil,
+			}
+			if pErc20ContractAddress != nil {
+				output.Erc20 = pErc20ContractAddress.Hex()
 			}
+
+			utils.PrintOutput(flags.ReadFlagOutputFormat(cmd), output, func() {
+				if output.Mined {
+					utils.PrintlnStdErr("INF: Tx executed successfully")
+				} else {
+					utils.PrintlnStdErr("WARN: Timed out waiting for tx to be mined")
+				}
+			})
 		},
 	}
 
 	cmd.Flags().String(flags.FlagEvmRpc, "", flags.FlagEvmRpcDesc)
-	cmd.Flags().String(flags.FlagSecretKey, "", flags.FlagSecretKeyDesc)
+	flags.AddSecretEvmAccountFlags(cmd)
 	cmd.Flags().String(flagErc20, "", "contract address if you want to send ERC-20 token instead of native coin")
-	cmd.Flags().String(flags.FlagGasLimit, "500k", fmt.Sprintf("%s. Ignored during normal EVM transfer, fixed to 21k", flagGasLimitDesc))
+	addEvmTxGasFlags(cmd, fmt.Sprintf("%s. Ignored during normal EVM transfer, fixed to 21k", flagGasLimitDesc))
 	cmd.Flags().String(flags.FlagGasPrices, "20b", flagGasPricesDesc)
+	addEvmTxTypeFlags(cmd)
+	addEvmTxOfflineFlags(cmd)
 	cmd.Flags().Bool(flagRawTx, false, flagRawTxDesc)
 
 	return cmd
 }
+
+type sendTxOutput struct {
+	TxHash string `json:"txHash"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+	Erc20  string `json:"erc20,omitempty"`
+	Mined  bool   `json:"mined"`
+}
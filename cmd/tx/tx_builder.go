@@ -0,0 +1,330 @@
+package tx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/flags"
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagTxType               = "tx-type"
+	flagMaxFeePerGas         = "max-fee-per-gas"
+	flagMaxPriorityFeePerGas = "max-priority-fee-per-gas"
+	flagAccessList           = "access-list"
+)
+
+const (
+	txTypeLegacy     = "legacy"
+	txTypeAccessList = "access-list"
+	txTypeDynamic    = "dynamic"
+)
+
+const (
+	// feeHistoryBlocks is number of recent blocks used to derive the priority fee when `eth_maxPriorityFeePerGas` is not supported
+	feeHistoryBlocks = 10
+	// feeHistoryRewardPercentile is the percentile of the priority fee paid in recent blocks, used to derive the priority fee
+	feeHistoryRewardPercentile = 50
+	// defaultGasAdjustment is the default multiplier applied to the estimated gas limit
+	defaultGasAdjustment = 1.2
+)
+
+// addEvmTxTypeFlags registers flags used to select tx type and fee model of EVM tx.
+func addEvmTxTypeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagTxType, txTypeLegacy, fmt.Sprintf("type of the EVM tx: %s (type 0), %s (type 1, EIP-2930) or %s (type 2, EIP-1559)", txTypeLegacy, txTypeAccessList, txTypeDynamic))
+	cmd.Flags().String(flagMaxFeePerGas, "", fmt.Sprintf("max fee per gas of %s tx, support short int and gwei like 30gwei. Default is 2 * base fee + priority fee", txTypeDynamic))
+	cmd.Flags().String(flagMaxPriorityFeePerGas, "", fmt.Sprintf("max priority fee per gas of %s tx, support short int and gwei like 2gwei. Default is derived from `eth_maxPriorityFeePerGas` or `eth_feeHistory`", txTypeDynamic))
+	cmd.Flags().String(flagAccessList, "", fmt.Sprintf("access list of %s/%s tx, JSON or path to JSON file, format: [{\"address\":\"0x..\",\"storageKeys\":[\"0x..\"]}]", txTypeAccessList, txTypeDynamic))
+}
+
+// evmTxFee holds the tx type and fee model of EVM tx to be built.
+type evmTxFee struct {
+	txType string
+
+	// gasPrice is used for legacy and access-list tx
+	gasPrice *big.Int
+
+	// gasFeeCap and gasTipCap are used for dynamic fee tx
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+
+	accessList ethtypes.AccessList
+}
+
+// mustReadEvmTxFee reads the tx type and fee flags.
+// Dynamic fee will be derived from the chain if not provided, the client is nil in offline mode,
+// fees must be provided explicitly via flags or network profile in offline mode.
+func mustReadEvmTxFee(cmd *cobra.Command, ethClient8545 *ethclient.Client, defaultGasPrices uint64) evmTxFee {
+	txType, _ := cmd.Flags().GetString(flagTxType)
+	txType = strings.ToLower(strings.TrimSpace(txType))
+
+	fee := evmTxFee{
+		txType: txType,
+	}
+
+	switch txType {
+	case txTypeLegacy, txTypeAccessList:
+		if ethClient8545 == nil && !cmd.Flags().Changed(flags.FlagGasPrices) {
+			if profile := flags.GetNetworkProfile(cmd); profile == nil || profile.GasPrices == "" {
+				utils.PrintfStdErr("ERR: flag --%s (or network profile) is required for %s tx in offline mode\n", flags.FlagGasPrices, txType)
+				os.Exit(1)
+			}
+		}
+		gasPrices, err := flags.ReadFlagGasPrices(cmd, flags.FlagGasPrices, defaultGasPrices)
+		utils.ExitOnErr(err, "failed to parse gas price")
+		fee.gasPrice = gasPrices
+	case txTypeDynamic:
+		if cmd.Flags().Changed(flags.FlagGasPrices) {
+			utils.PrintfStdErr("WARN: flag --%s is ignored for %s tx, use --%s and --%s instead\n", flags.FlagGasPrices, txTypeDynamic, flagMaxFeePerGas, flagMaxPriorityFeePerGas)
+		}
+		fee.gasFeeCap, fee.gasTipCap = mustReadDynamicFee(cmd, ethClient8545)
+	default:
+		utils.PrintfStdErr("ERR: invalid tx type [%s], must be one of: %s, %s, %s\n", txType, txTypeLegacy, txTypeAccessList, txTypeDynamic)
+		os.Exit(1)
+	}
+
+	accessListInput, _ := cmd.Flags().GetString(flagAccessList)
+	if accessListInput != "" {
+		if txType == txTypeLegacy {
+			utils.PrintfStdErr("ERR: %s tx does not support access list, use --%s %s or --%s %s\n", txTypeLegacy, flagTxType, txTypeAccessList, flagTxType, txTypeDynamic)
+			os.Exit(1)
+		}
+
+		accessList, err := parseAccessList(accessListInput)
+		utils.ExitOnErr(err, "failed to parse access list")
+		fee.accessList = accessList
+	}
+
+	return fee
+}
+
+func mustReadDynamicFee(cmd *cobra.Command, ethClient8545 *ethclient.Client) (gasFeeCap, gasTipCap *big.Int) {
+	readFee := func(flag string) *big.Int {
+		if !cmd.Flags().Changed(flag) {
+			return nil
+		}
+		fee, err := flags.ReadFlagGasPrices(cmd, flag, 0)
+		utils.ExitOnErr(err, fmt.Sprintf("failed to parse --%s", flag))
+		return fee
+	}
+
+	gasFeeCap = readFee(flagMaxFeePerGas)
+	gasTipCap = readFee(flagMaxPriorityFeePerGas)
+
+	if ethClient8545 == nil && (gasFeeCap == nil || gasTipCap == nil) {
+		utils.PrintfStdErr("ERR: flags --%s and --%s are required for %s tx in offline mode\n", flagMaxFeePerGas, flagMaxPriorityFeePerGas, txTypeDynamic)
+		os.Exit(1)
+	}
+
+	if gasTipCap == nil {
+		var err error
+		gasTipCap, err = suggestGasTipCap(ethClient8545)
+		utils.ExitOnErr(err, fmt.Sprintf("failed to derive max priority fee per gas, please provide via --%s", flagMaxPriorityFeePerGas))
+		utils.PrintlnStdErr("INF: using max priority fee per gas", gasTipCap.String(), "(suggested)")
+	}
+
+	if gasFeeCap == nil {
+		header, err := ethClient8545.HeaderByNumber(context.Background(), nil)
+		utils.ExitOnErr(err, "failed to get latest block header")
+		if header.BaseFee == nil {
+			utils.PrintfStdErr("ERR: chain does not support EIP-1559, base fee is missing, use --%s %s instead\n", flagTxType, txTypeLegacy)
+			os.Exit(1)
+		}
+
+		gasFeeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), gasTipCap)
+		utils.PrintlnStdErr("INF: using max fee per gas", gasFeeCap.String(), "(2 * base fee", header.BaseFee.String(), "+ priority fee)")
+	}
+
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		utils.PrintfStdErr("ERR: max fee per gas %s is lower than max priority fee per gas %s\n", gasFeeCap, gasTipCap)
+		os.Exit(1)
+	}
+
+	return
+}
+
+// suggestGasTipCap derives the priority fee from `eth_maxPriorityFeePerGas`,
+// fallback to the median priority fee of recent blocks from `eth_feeHistory`.
+func suggestGasTipCap(ethClient8545 *ethclient.Client) (*big.Int, error) {
+	gasTipCap, err := ethClient8545.SuggestGasTipCap(context.Background())
+	if err == nil {
+		return gasTipCap, nil
+	}
+	utils.PrintlnStdErr("WARN: failed to get `eth_maxPriorityFeePerGas`, fallback to `eth_feeHistory`:", err)
+
+	feeHistory, err := ethClient8545.FeeHistory(context.Background(), feeHistoryBlocks, nil, []float64{feeHistoryRewardPercentile})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fee history")
+	}
+
+	var rewards []*big.Int
+	for _, blockRewards := range feeHistory.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+	if len(rewards) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	return rewards[len(rewards)/2], nil
+}
+
+// parseAccessList parses the access list from JSON or JSON file.
+func parseAccessList(input string) (ethtypes.AccessList, error) {
+	bz := []byte(strings.TrimSpace(input))
+	if !strings.HasPrefix(string(bz), "[") {
+		var err error
+		bz, err = os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read access list file")
+		}
+	}
+
+	var accessList ethtypes.AccessList
+	if err := json.Unmarshal(bz, &accessList); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal access list")
+	}
+
+	return accessList, nil
+}
+
+// intrinsicGasOfAccessList returns the additional intrinsic gas required by the access list, see EIP-2930.
+func intrinsicGasOfAccessList(accessList ethtypes.AccessList) uint64 {
+	const (
+		accessListAddressGas    = 2400
+		accessListStorageKeyGas = 1900
+	)
+	return uint64(len(accessList))*accessListAddressGas + uint64(accessList.StorageKeys())*accessListStorageKeyGas
+}
+
+// addEvmTxGasFlags registers flags used to provide or estimate gas limit of EVM tx.
+func addEvmTxGasFlags(cmd *cobra.Command, desc string) {
+	cmd.Flags().String(flags.FlagGasLimit, flags.GasLimitAuto, desc)
+	cmd.Flags().Float64(flags.FlagGasAdjustment, defaultGasAdjustment, flags.FlagGasAdjustmentDesc)
+}
+
+// mustReadGasLimitOrEstimate reads the gas limit from flag,
+// or estimates via `eth_estimateGas` then applies the gas adjustment if the gas limit is auto.
+func mustReadGasLimitOrEstimate(cmd *cobra.Command, ethClient8545 *ethclient.Client, msg ethereum.CallMsg) uint64 {
+	gasLimit, auto, err := flags.ReadFlagGasLimitOrAuto(cmd, flags.FlagGasLimit, 500_000)
+	utils.ExitOnErr(err, "failed to parse gas limit")
+	if !auto {
+		return gasLimit
+	}
+
+	if ethClient8545 == nil {
+		utils.PrintfStdErr("ERR: gas limit cannot be estimated in offline mode, provide via --%s\n", flags.FlagGasLimit)
+		os.Exit(1)
+	}
+
+	gasAdjustment, err := flags.ReadFlagGasAdjustment(cmd)
+	utils.ExitOnErr(err, "failed to parse gas adjustment")
+
+	estimatedGas, err := ethClient8545.EstimateGas(context.Background(), msg)
+	if err != nil {
+		if reason, ok := tryDecodeRevertReasonFromRpcError(err); ok {
+			utils.PrintfStdErr("ERR: failed to estimate gas, EVM execution reverted with message [%s]\n", reason)
+			os.Exit(1)
+		}
+		utils.ExitOnErr(err, fmt.Sprintf("failed to estimate gas, provide gas limit via --%s to skip estimation", flags.FlagGasLimit))
+	}
+
+	gasLimit = uint64(math.Ceil(float64(estimatedGas) * gasAdjustment))
+	utils.PrintlnStdErr("INF: Estimated gas", estimatedGas, "adjusted to", gasLimit, fmt.Sprintf("(x%g)", gasAdjustment))
+	return gasLimit
+}
+
+// tryDecodeRevertReasonFromRpcError decodes the revert reason from the data of the Json-RPC error, if any.
+func tryDecodeRevertReasonFromRpcError(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return "", false
+	}
+
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return "", false
+	}
+
+	bz, err := hexutil.Decode(data)
+	if err != nil {
+		return "", false
+	}
+
+	return utils.TryDecodeRevertReason(bz)
+}
+
+// callMsg builds the message used to estimate gas of the tx.
+func (f evmTxFee) callMsg(from common.Address, to *common.Address, value *big.Int, data []byte) ethereum.CallMsg {
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: f.accessList,
+	}
+	if f.txType == txTypeDynamic {
+		msg.GasFeeCap = f.gasFeeCap
+		msg.GasTipCap = f.gasTipCap
+	} else {
+		msg.GasPrice = f.gasPrice
+	}
+	return msg
+}
+
+// newTx builds the unsigned EVM tx based on tx type.
+func (f evmTxFee) newTx(chainId *big.Int, nonce uint64, to *common.Address, value *big.Int, gas uint64, data []byte) *ethtypes.Transaction {
+	switch f.txType {
+	case txTypeAccessList:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    chainId,
+			Nonce:      nonce,
+			GasPrice:   f.gasPrice,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: f.accessList,
+		})
+	case txTypeDynamic:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    chainId,
+			Nonce:      nonce,
+			GasTipCap:  f.gasTipCap,
+			GasFeeCap:  f.gasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: f.accessList,
+		})
+	default:
+		return ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: f.gasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	}
+}
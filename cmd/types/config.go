@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/bcdevtools/devd/v3/constants"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+)
+
+// NetworkProfile holds the endpoints and default settings of a named network.
+type NetworkProfile struct {
+	EvmRpc     string `toml:"evm-rpc,omitempty" json:"evmRpc,omitempty"`
+	TmRpc      string `toml:"tm-rpc,omitempty" json:"tmRpc,omitempty"`
+	CosmosRest string `toml:"rest,omitempty" json:"rest,omitempty"`
+	Bech32Hrp  string `toml:"bech32-hrp,omitempty" json:"bech32Hrp,omitempty"`
+	ChainId    string `toml:"chain-id,omitempty" json:"chainId,omitempty"` // EVM chain ID
+	GasPrices  string `toml:"gas-prices,omitempty" json:"gasPrices,omitempty"`
+	GasLimit   string `toml:"gas,omitempty" json:"gas,omitempty"`
+}
+
+// Config is the content of the config file, default location is ~/.devd/config.toml
+type Config struct {
+	// Network is the name of the network profile to be used when no network is provided via flag
+	Network string `toml:"network,omitempty"`
+
+	Networks map[string]NetworkProfile `toml:"networks,omitempty"`
+}
+
+var patternNetworkProfileName = regexp.MustCompile(`^[\w.-]+$`)
+
+// ValidateNetworkProfileName returns error if the given name is not a valid network profile name.
+func ValidateNetworkProfileName(name string) error {
+	if !patternNetworkProfileName.MatchString(name) {
+		return fmt.Errorf("invalid network profile name [%s], only alphabet, digits, '_', '-' and '.' are allowed", name)
+	}
+	return nil
+}
+
+// GetConfigFilePath returns path of the config file, default is ~/.devd/config.toml
+func GetConfigFilePath() (string, error) {
+	homeDir, err := utils.GetDevdHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, constants.CONFIG_FILE_NAME), nil
+}
+
+// LoadConfig reads the config from the given file.
+// If the file does not exist, an empty config will be returned.
+func LoadConfig(file string) (*Config, error) {
+	config := &Config{}
+
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, errors.Wrap(err, "failed to read config file")
+	}
+
+	err = toml.Unmarshal(bz, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse config file %s", file)
+	}
+
+	return config, nil
+}
+
+// SaveConfig writes the config into the given file, parent directory will be created if not exists.
+func SaveConfig(file string, config *Config) error {
+	bz, err := toml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode config")
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0o700)
+	if err != nil {
+		return errors.Wrap(err, "failed to create config directory")
+	}
+
+	err = os.WriteFile(file, bz, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to write config file")
+	}
+
+	return nil
+}
+
+// GetNetworkProfile returns the network profile by name.
+func (c *Config) GetNetworkProfile(name string) (profile NetworkProfile, found bool) {
+	if c.Networks == nil {
+		return
+	}
+	profile, found = c.Networks[name]
+	return
+}
+
+// SetNetworkProfile adds or replaces the network profile with the given name.
+func (c *Config) SetNetworkProfile(name string, profile NetworkProfile) {
+	if c.Networks == nil {
+		c.Networks = make(map[string]NetworkProfile)
+	}
+	c.Networks[name] = profile
+}
+
+// RemoveNetworkProfile removes the network profile with the given name,
+// the selection will be cleared if the removed profile is the selected one.
+func (c *Config) RemoveNetworkProfile(name string) (removed bool) {
+	if _, found := c.Networks[name]; !found {
+		return false
+	}
+	delete(c.Networks, name)
+	if c.Network == name {
+		c.Network = ""
+	}
+	return true
+}
+
+// SortedNetworkProfileNames returns names of all network profiles, sorted alphabetically.
+func (c *Config) SortedNetworkProfileNames() []string {
+	names := make([]string, 0, len(c.Networks))
+	for name := range c.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
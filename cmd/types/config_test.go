@@ -0,0 +1,106 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("not exists file returns empty config", func(t *testing.T) {
+		config, err := LoadConfig(filepath.Join(t.TempDir(), "config.toml"))
+		require.NoError(t, err)
+		require.NotNil(t, config)
+		require.Empty(t, config.Network)
+		require.Empty(t, config.Networks)
+	})
+
+	t.Run("parse config file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.toml")
+		require.NoError(t, os.WriteFile(file, []byte(`
+network = "devnet"
+
+[networks.devnet]
+evm-rpc = "http://localhost:8545"
+tm-rpc = "http://localhost:26657"
+rest = "http://localhost:1317"
+bech32-hrp = "ethm"
+chain-id = "9000"
+gas-prices = "20b"
+gas = "500k"
+
+[networks.testnet]
+evm-rpc = "https://evm.testnet.example.com"
+`), 0o600))
+
+		config, err := LoadConfig(file)
+		require.NoError(t, err)
+		require.Equal(t, "devnet", config.Network)
+		require.Len(t, config.Networks, 2)
+
+		profile, found := config.GetNetworkProfile("devnet")
+		require.True(t, found)
+		require.Equal(t, NetworkProfile{
+			EvmRpc:     "http://localhost:8545",
+			TmRpc:      "http://localhost:26657",
+			CosmosRest: "http://localhost:1317",
+			Bech32Hrp:  "ethm",
+			ChainId:    "9000",
+			GasPrices:  "20b",
+			GasLimit:   "500k",
+		}, profile)
+
+		profile, found = config.GetNetworkProfile("testnet")
+		require.True(t, found)
+		require.Equal(t, "https://evm.testnet.example.com", profile.EvmRpc)
+		require.Empty(t, profile.TmRpc)
+
+		_, found = config.GetNetworkProfile("mainnet")
+		require.False(t, found)
+	})
+
+	t.Run("malformed config file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config.toml")
+		require.NoError(t, os.WriteFile(file, []byte(`network = `), 0o600))
+
+		_, err := LoadConfig(file)
+		require.Error(t, err)
+	})
+}
+
+func TestSaveConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "nested", "config.toml")
+
+	config := &Config{}
+	config.SetNetworkProfile("devnet", NetworkProfile{
+		EvmRpc:    "http://localhost:8545",
+		Bech32Hrp: "ethm",
+	})
+	config.SetNetworkProfile("testnet", NetworkProfile{
+		EvmRpc: "https://evm.testnet.example.com",
+	})
+	config.Network = "devnet"
+
+	require.NoError(t, SaveConfig(file, config))
+
+	loaded, err := LoadConfig(file)
+	require.NoError(t, err)
+	require.Equal(t, config, loaded)
+	require.Equal(t, []string{"devnet", "testnet"}, loaded.SortedNetworkProfileNames())
+
+	require.True(t, loaded.RemoveNetworkProfile("devnet"))
+	require.Empty(t, loaded.Network, "selection must be cleared when the selected profile is removed")
+	require.False(t, loaded.RemoveNetworkProfile("devnet"))
+	require.Equal(t, []string{"testnet"}, loaded.SortedNetworkProfileNames())
+}
+
+func TestValidateNetworkProfileName(t *testing.T) {
+	for _, name := range []string{"devnet", "evmos_9000-1", "test.net", "A1"} {
+		require.NoError(t, ValidateNetworkProfileName(name), name)
+	}
+	for _, name := range []string{"", "dev net", "dev/net", "\"devnet\""} {
+		require.Error(t, ValidateNetworkProfileName(name), name)
+	}
+}
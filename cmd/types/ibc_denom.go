@@ -0,0 +1,189 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/bcdevtools/devd/v3/constants"
+	"github.com/pkg/errors"
+)
+
+const (
+	IbcDenomPrefix = "ibc/"
+
+	ibcDenomTraceCacheFileName = "ibc_denom_traces.json"
+)
+
+var (
+	patternIbcDenomHash  = regexp.MustCompile(`^[a-fA-F\d]{64}$`)
+	patternIbcChannelId  = regexp.MustCompile(`^channel-\d+$`)
+	patternIbcDenomInStr = regexp.MustCompile(`ibc/[a-fA-F\d]{64}`)
+)
+
+// IbcDenomTrace is the trace of an IBC voucher denom, the path of port/channel pairs it was transferred through
+// and the base denom on the source chain.
+type IbcDenomTrace struct {
+	Path      string `json:"path"`
+	BaseDenom string `json:"baseDenom"`
+}
+
+// ParseIbcDenomTrace parses the full path of the denom, eg: transfer/channel-0/uatom, into trace.
+// Leading port/channel pairs are the path, the rest is the base denom which can contain '/'.
+func ParseIbcDenomTrace(fullPath string) IbcDenomTrace {
+	parts := strings.Split(fullPath, "/")
+
+	var i int
+	for i+1 < len(parts)-1 && patternIbcChannelId.MatchString(parts[i+1]) {
+		i += 2
+	}
+
+	return IbcDenomTrace{
+		Path:      strings.Join(parts[:i], "/"),
+		BaseDenom: strings.Join(parts[i:], "/"),
+	}
+}
+
+// FullPath returns the path and base denom joined by '/', or the base denom if the path is empty.
+func (t IbcDenomTrace) FullPath() string {
+	if t.Path == "" {
+		return t.BaseDenom
+	}
+	return t.Path + "/" + t.BaseDenom
+}
+
+// Hash returns the upper-case hex SHA256 of the full path.
+func (t IbcDenomTrace) Hash() string {
+	hash := sha256.Sum256([]byte(t.FullPath()))
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// IbcDenom returns the IBC voucher denom, ibc/{hash}, or the base denom if the path is empty (native denom).
+func (t IbcDenomTrace) IbcDenom() string {
+	if t.Path == "" {
+		return t.BaseDenom
+	}
+	return IbcDenomPrefix + t.Hash()
+}
+
+// ParseIbcDenomHash returns the upper-case hash of the IBC voucher denom, provided as ibc/{hash} or {hash}.
+func ParseIbcDenomHash(denom string) (hash string, ok bool) {
+	hash = strings.TrimPrefix(denom, IbcDenomPrefix)
+	if !patternIbcDenomHash.MatchString(hash) {
+		return "", false
+	}
+	return strings.ToUpper(hash), true
+}
+
+// FindIbcDenoms returns the distinct IBC voucher denoms found in the string, eg: amount attribute 100ibc/{hash}.
+func FindIbcDenoms(str string) []string {
+	var denoms []string
+	unique := make(map[string]struct{})
+	for _, match := range patternIbcDenomInStr.FindAllString(str, -1) {
+		denom := IbcDenomPrefix + strings.ToUpper(strings.TrimPrefix(match, IbcDenomPrefix))
+		if _, found := unique[denom]; found {
+			continue
+		}
+		unique[denom] = struct{}{}
+		denoms = append(denoms, denom)
+	}
+	return denoms
+}
+
+// GetIbcDenomTraceCacheFilePath returns path of the IBC denom trace cache file, default is ~/.devd/cache/ibc_denom_traces.json
+func GetIbcDenomTraceCacheFilePath() (string, error) {
+	homeDir, err := utils.GetDevdHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, constants.CACHE_DIR_NAME, ibcDenomTraceCacheFileName), nil
+}
+
+// IbcDenomTraceCache is a local cache of IBC denom traces, indexed by the upper-case hash.
+// Since the hash is derived from the trace, the cache is valid across chains.
+type IbcDenomTraceCache struct {
+	mu     sync.Mutex
+	file   string
+	traces map[string]IbcDenomTrace
+	dirty  bool
+}
+
+// LoadIbcDenomTraceCache reads the cache from the given file.
+// If the file does not exist, an empty cache will be returned.
+func LoadIbcDenomTraceCache(file string) (*IbcDenomTraceCache, error) {
+	cache := &IbcDenomTraceCache{
+		file:   file,
+		traces: make(map[string]IbcDenomTrace),
+	}
+
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, errors.Wrap(err, "failed to read IBC denom trace cache file")
+	}
+
+	if err := json.Unmarshal(bz, &cache.traces); err != nil {
+		return nil, errors.Wrap(err, "failed to parse IBC denom trace cache file")
+	}
+
+	return cache, nil
+}
+
+// Get returns the cached trace of the hash.
+func (c *IbcDenomTraceCache) Get(hash string) (IbcDenomTrace, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	trace, found := c.traces[strings.ToUpper(hash)]
+	return trace, found
+}
+
+// Set caches the trace, returns error if the trace does not match the hash.
+func (c *IbcDenomTraceCache) Set(hash string, trace IbcDenomTrace) error {
+	hash = strings.ToUpper(hash)
+	if trace.Hash() != hash {
+		return fmt.Errorf("IBC denom trace %s does not match the hash %s", trace.FullPath(), hash)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.traces[hash] = trace
+	c.dirty = true
+	return nil
+}
+
+// Save writes the cache to the file if there is any change.
+func (c *IbcDenomTraceCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	bz, err := json.MarshalIndent(c.traces, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal IBC denom trace cache")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.file), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create cache directory")
+	}
+
+	if err := os.WriteFile(c.file, bz, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write IBC denom trace cache file")
+	}
+
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAtomOnOsmosisHash = "27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestParseIbcDenomTrace(t *testing.T) {
+	tests := []struct {
+		fullPath      string
+		wantPath      string
+		wantBaseDenom string
+	}{
+		{
+			fullPath:      "transfer/channel-0/uatom",
+			wantPath:      "transfer/channel-0",
+			wantBaseDenom: "uatom",
+		},
+		{
+			fullPath:      "transfer/channel-1/transfer/channel-22/uosmo",
+			wantPath:      "transfer/channel-1/transfer/channel-22",
+			wantBaseDenom: "uosmo",
+		},
+		{
+			fullPath:      "transfer/channel-3/gamm/pool/1",
+			wantPath:      "transfer/channel-3",
+			wantBaseDenom: "gamm/pool/1",
+		},
+		{
+			fullPath:      "uatom",
+			wantPath:      "",
+			wantBaseDenom: "uatom",
+		},
+		{
+			fullPath:      "transfer/channel-0",
+			wantPath:      "",
+			wantBaseDenom: "transfer/channel-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fullPath, func(t *testing.T) {
+			trace := ParseIbcDenomTrace(tt.fullPath)
+			require.Equal(t, tt.wantPath, trace.Path)
+			require.Equal(t, tt.wantBaseDenom, trace.BaseDenom)
+			require.Equal(t, tt.fullPath, trace.FullPath())
+		})
+	}
+}
+
+func TestIbcDenomTrace_IbcDenom(t *testing.T) {
+	trace := ParseIbcDenomTrace("transfer/channel-0/uatom")
+	require.Equal(t, testAtomOnOsmosisHash, trace.Hash())
+	require.Equal(t, "ibc/"+testAtomOnOsmosisHash, trace.IbcDenom())
+
+	require.Equal(t, "uatom", ParseIbcDenomTrace("uatom").IbcDenom(), "native denom")
+}
+
+func TestParseIbcDenomHash(t *testing.T) {
+	for _, denom := range []string{"ibc/" + testAtomOnOsmosisHash, testAtomOnOsmosisHash, "ibc/27394fb092d2eccd56123c74f36e4c1f926001ceada9ca97ea622b25f41e5eb2"} {
+		hash, ok := ParseIbcDenomHash(denom)
+		require.True(t, ok, denom)
+		require.Equal(t, testAtomOnOsmosisHash, hash)
+	}
+
+	for _, denom := range []string{"uatom", "ibc/", "ibc/XYZ", "transfer/channel-0/uatom"} {
+		_, ok := ParseIbcDenomHash(denom)
+		require.False(t, ok, denom)
+	}
+}
+
+func TestFindIbcDenoms(t *testing.T) {
+	lowerHash := "ibc/27394fb092d2eccd56123c74f36e4c1f926001ceada9ca97ea622b25f41e5eb2"
+	require.Equal(t,
+		[]string{"ibc/" + testAtomOnOsmosisHash},
+		FindIbcDenoms("100ibc/"+testAtomOnOsmosisHash+",5"+lowerHash+",1uatom"),
+	)
+	require.Empty(t, FindIbcDenoms("1uatom"))
+}
+
+func TestIbcDenomTraceCache(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cache", "ibc_denom_traces.json")
+
+	cache, err := LoadIbcDenomTraceCache(file)
+	require.NoError(t, err)
+
+	_, found := cache.Get(testAtomOnOsmosisHash)
+	require.False(t, found)
+
+	trace := ParseIbcDenomTrace("transfer/channel-0/uatom")
+	require.Error(t, cache.Set(testAtomOnOsmosisHash, ParseIbcDenomTrace("transfer/channel-1/uatom")), "mismatch hash")
+	require.NoError(t, cache.Set(testAtomOnOsmosisHash, trace))
+	require.NoError(t, cache.Save())
+
+	reloaded, err := LoadIbcDenomTraceCache(file)
+	require.NoError(t, err)
+
+	cached, found := reloaded.Get("27394fb092d2eccd56123c74f36e4c1f926001ceada9ca97ea622b25f41e5eb2")
+	require.True(t, found)
+	require.Equal(t, trace, cached)
+}
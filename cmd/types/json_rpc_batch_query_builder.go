@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/pkg/errors"
+)
+
+// JsonRpcBatchQueryBuilder groups multiple JSON-RPC requests into a single JSON-RPC batch request.
+type JsonRpcBatchQueryBuilder interface {
+	// Add appends the requests into the batch
+	Add(qbs ...JsonRpcQueryBuilder) JsonRpcBatchQueryBuilder
+
+	// Size returns number of requests in the batch
+	Size() int
+
+	// Queries returns the requests in the batch
+	Queries() []JsonRpcQueryBuilder
+
+	// String returns the JSON-RPC batch request body, which is a JSON array of requests
+	String() string
+}
+
+var _ JsonRpcBatchQueryBuilder = &jsonRpcBatchQueryBuilder{}
+
+type jsonRpcBatchQueryBuilder struct {
+	queries []JsonRpcQueryBuilder
+}
+
+func NewJsonRpcBatchQueryBuilder(qbs ...JsonRpcQueryBuilder) JsonRpcBatchQueryBuilder {
+	return &jsonRpcBatchQueryBuilder{
+		queries: append([]JsonRpcQueryBuilder{}, qbs...),
+	}
+}
+
+func (b *jsonRpcBatchQueryBuilder) Add(qbs ...JsonRpcQueryBuilder) JsonRpcBatchQueryBuilder {
+	b.queries = append(b.queries, qbs...)
+	return b
+}
+
+func (b *jsonRpcBatchQueryBuilder) Size() int {
+	return len(b.queries)
+}
+
+func (b *jsonRpcBatchQueryBuilder) Queries() []JsonRpcQueryBuilder {
+	return b.queries
+}
+
+func (b *jsonRpcBatchQueryBuilder) String() string {
+	sb := strings.Builder{}
+	sb.WriteRune('[')
+	for i, qb := range b.queries {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+		sb.WriteString(qb.String())
+	}
+	sb.WriteRune(']')
+	return sb.String()
+}
+
+// DoEvmRpcBatchQuery sends the batch request to the EVM Json-RPC in a single HTTP POST,
+// then demultiplexes the responses by request id.
+// Each value of the output map is the raw response of the corresponding request,
+// which can be parsed by ParseJsonRpcResponse.
+func DoEvmRpcBatchQuery(host string, bqb JsonRpcBatchQueryBuilder, optionalTimeout time.Duration) (map[uint64][]byte, error) {
+	if bqb.Size() < 1 {
+		return map[uint64][]byte{}, nil
+	}
+
+	timeout := optionalTimeout
+	if optionalTimeout == 0 {
+		timeout = generalEvmQueryTimeout
+	}
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+
+	utils.PrintlnStdErr("INF: Querying", host, "batch of", bqb.Size(), "requests")
+
+	bz, err := doEvmRpcHttpPost(host, bqb.String(), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return demultiplexJsonRpcBatchResponse(bz, bqb)
+}
+
+func demultiplexJsonRpcBatchResponse(bz []byte, bqb JsonRpcBatchQueryBuilder) (map[uint64][]byte, error) {
+	var responses []json.RawMessage
+	if err := json.Unmarshal(bz, &responses); err != nil {
+		// some nodes returns a single error object when batch request is not supported
+		if _, errParse := ParseJsonRpcResponse[json.RawMessage](bz); errParse != nil {
+			return nil, errors.Wrap(errParse, "batch request is rejected")
+		}
+		return nil, errors.Wrap(err, "failed to unmarshal batch response")
+	}
+
+	type responseIdStruct struct {
+		Id *uint64 `json:"id"`
+	}
+
+	result := make(map[uint64][]byte, len(responses))
+	for _, response := range responses {
+		var responseId responseIdStruct
+		if err := json.Unmarshal(response, &responseId); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal response in batch")
+		}
+		if responseId.Id == nil {
+			continue
+		}
+		result[*responseId.Id] = response
+	}
+
+	for _, qb := range bqb.Queries() {
+		if _, found := result[qb.RequestId()]; !found {
+			return nil, fmt.Errorf("missing response for request id %d in batch response", qb.RequestId())
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewJsonRpcBatchQueryBuilder(t *testing.T) {
+	t.Run("empty batch", func(t *testing.T) {
+		bqb := NewJsonRpcBatchQueryBuilder()
+		require.Zero(t, bqb.Size())
+		require.Equal(t, "[]", bqb.String())
+	})
+
+	t.Run("output string must be array of requests", func(t *testing.T) {
+		qb1 := NewJsonRpcQueryBuilder("x_req1")
+		qb2 := NewJsonRpcQueryBuilder("x_req2", NewJsonRpcIntQueryParam(16))
+		bqb := NewJsonRpcBatchQueryBuilder(qb1).Add(qb2)
+		require.Equal(t, 2, bqb.Size())
+		require.Equal(t, []JsonRpcQueryBuilder{qb1, qb2}, bqb.Queries())
+		require.Equal(t, "["+qb1.String()+","+qb2.String()+"]", bqb.String())
+
+		var requests []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(bqb.String()), &requests), "must be a valid JSON array")
+		require.Len(t, requests, 2)
+	})
+}
+
+func Test_DoEvmRpcBatchQuery(t *testing.T) {
+	newServer := func(t *testing.T, handle func(requests []map[string]any) string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bz, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var requests []map[string]any
+			require.NoError(t, json.Unmarshal(bz, &requests))
+			_, _ = w.Write([]byte(handle(requests)))
+		}))
+	}
+
+	t.Run("responses are demultiplexed by id", func(t *testing.T) {
+		server := newServer(t, func(requests []map[string]any) string {
+			// reply in reverse order
+			var responses []string
+			for i := len(requests) - 1; i >= 0; i-- {
+				id := uint64(requests[i]["id"].(float64))
+				if requests[i]["method"] == "x_err" {
+					responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32000,"message":"execution reverted"}}`, id))
+				} else {
+					responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"%s"}`, id, requests[i]["method"]))
+				}
+			}
+			return "[" + strings.Join(responses, ",") + "]"
+		})
+		defer server.Close()
+
+		qb1 := NewJsonRpcQueryBuilder("x_req1")
+		qb2 := NewJsonRpcQueryBuilder("x_req2")
+		qb3 := NewJsonRpcQueryBuilder("x_err")
+
+		responses, err := DoEvmRpcBatchQuery(server.URL, NewJsonRpcBatchQueryBuilder(qb1, qb2, qb3), 0)
+		require.NoError(t, err)
+		require.Len(t, responses, 3)
+
+		res, err := ParseJsonRpcResponse[string](responses[qb1.RequestId()])
+		require.NoError(t, err)
+		require.Equal(t, "x_req1", *(res.(*string)))
+
+		res, err = ParseJsonRpcResponse[string](responses[qb2.RequestId()])
+		require.NoError(t, err)
+		require.Equal(t, "x_req2", *(res.(*string)))
+
+		_, err = ParseJsonRpcResponse[string](responses[qb3.RequestId()])
+		require.ErrorIs(t, err, ErrUpstreamRpcReturnedError)
+	})
+
+	t.Run("error when missing response", func(t *testing.T) {
+		server := newServer(t, func(requests []map[string]any) string {
+			return fmt.Sprintf(`[{"jsonrpc":"2.0","id":%d,"result":"0x1"}]`, uint64(requests[0]["id"].(float64)))
+		})
+		defer server.Close()
+
+		_, err := DoEvmRpcBatchQuery(server.URL, NewJsonRpcBatchQueryBuilder(NewJsonRpcQueryBuilder("x_req1"), NewJsonRpcQueryBuilder("x_req2")), 0)
+		require.ErrorContains(t, err, "missing response")
+	})
+
+	t.Run("error when batch is rejected", func(t *testing.T) {
+		server := newServer(t, func(_ []map[string]any) string {
+			return `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch too large"}}`
+		})
+		defer server.Close()
+
+		_, err := DoEvmRpcBatchQuery(server.URL, NewJsonRpcBatchQueryBuilder(NewJsonRpcQueryBuilder("x_req1")), 0)
+		require.ErrorContains(t, err, "batch too large")
+	})
+
+	t.Run("empty batch does not send request", func(t *testing.T) {
+		responses, err := DoEvmRpcBatchQuery("http://invalid.invalid", NewJsonRpcBatchQueryBuilder(), 0)
+		require.NoError(t, err)
+		require.Empty(t, responses)
+	})
+}
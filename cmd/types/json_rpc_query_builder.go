@@ -2,14 +2,17 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 )
 
@@ -30,6 +33,10 @@ func nextRequestId() uint64 {
 }
 
 type JsonRpcQueryBuilder interface {
+	// RequestId returns the id of the request, used to match the response
+	RequestId() uint64
+
+	// String returns the JSON-RPC request body
 	String() string
 }
 
@@ -52,14 +59,21 @@ func NewJsonRpcQueryBuilder(
 	}
 }
 
+func (j *jsonRpcQueryBuilder) RequestId() uint64 {
+	return j.requestId
+}
+
 func (j *jsonRpcQueryBuilder) String() string {
+	method, err := json.Marshal(j.method)
+	utils.PanicIfErr(err, "failed to encode method")
+
 	return fmt.Sprintf(`{
-    "method": "%s",
+    "method": %s,
     "params": [%s],
     "id": %d,
     "jsonrpc": "2.0"
 }`,
-		j.method,
+		string(method),
 		func() string {
 			if len(j.queryParams) == 0 {
 				return ""
@@ -84,135 +98,93 @@ type JsonRpcQueryParam interface {
 	// IsArray returns true if the param is array formed
 	IsArray() bool
 
-	// String returns string of the param
+	// String returns JSON encoded string of the param
 	String() string
 }
 
-var _ JsonRpcQueryParam = jsonRpcIntegerQueryParam{}
-
-type jsonRpcIntegerQueryParam struct {
-	value string
-}
-
-func NewJsonRpcInt64QueryParam(num int64) JsonRpcQueryParam {
-	return jsonRpcIntegerQueryParam{fmt.Sprintf("%d", num)}
-}
-
-func NewJsonRpcIntQueryParam(num int) JsonRpcQueryParam {
-	return jsonRpcIntegerQueryParam{fmt.Sprintf("%d", num)}
-}
+var _ JsonRpcQueryParam = jsonRpcQueryParam{}
 
-func (j jsonRpcIntegerQueryParam) IsArray() bool {
-	return false
+// jsonRpcQueryParam holds the JSON encoded form of a param
+type jsonRpcQueryParam struct {
+	encoded string
+	isArray bool
 }
 
-func (j jsonRpcIntegerQueryParam) String() string {
-	return j.value
+// NewJsonRpcQueryParam creates a param from any value can be encoded by `encoding/json`,
+// like string, number, bool, slice, map, struct, json.RawMessage,...
+func NewJsonRpcQueryParam(value any) (JsonRpcQueryParam, error) {
+	bz, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode query param")
+	}
+	return newJsonRpcEncodedQueryParam(string(bz)), nil
 }
 
-var _ JsonRpcQueryParam = jsonRpcStringQueryParam{}
-
-type jsonRpcStringQueryParam struct {
-	value string
+// MustNewJsonRpcQueryParam is the same as NewJsonRpcQueryParam but panics on error.
+// Use it only for values those always can be encoded.
+func MustNewJsonRpcQueryParam(value any) JsonRpcQueryParam {
+	param, err := NewJsonRpcQueryParam(value)
+	utils.PanicIfErr(err, "failed to build query param")
+	return param
 }
 
-func NewJsonRpcStringQueryParam(str string) (JsonRpcQueryParam, error) {
-	if strings.Contains(str, "\"") {
-		return nil, fmt.Errorf("prohibited double quote on string param: %s", str)
+func newJsonRpcEncodedQueryParam(encoded string) JsonRpcQueryParam {
+	return jsonRpcQueryParam{
+		encoded: encoded,
+		isArray: strings.HasPrefix(strings.TrimSpace(encoded), "["),
 	}
-	return jsonRpcStringQueryParam{str}, nil
 }
 
-func (j jsonRpcStringQueryParam) IsArray() bool {
-	return false
+func (j jsonRpcQueryParam) IsArray() bool {
+	return j.isArray
 }
 
-func (j jsonRpcStringQueryParam) String() string {
-	return fmt.Sprintf("\"%s\"", j.value)
+func (j jsonRpcQueryParam) String() string {
+	return j.encoded
 }
 
-var _ JsonRpcQueryParam = jsonRpcStringArrayQueryParam{}
-
-type jsonRpcStringArrayQueryParam struct {
-	value []string
+func NewJsonRpcInt64QueryParam(num int64) JsonRpcQueryParam {
+	return MustNewJsonRpcQueryParam(num)
 }
 
-func NewJsonRpcStringArrayQueryParam(strArr ...string) (JsonRpcQueryParam, error) {
-	for _, str := range strArr {
-		if strings.Contains(str, "\"") {
-			return nil, fmt.Errorf("prohibited double quote on string param: %s", str)
-		}
-	}
-	return jsonRpcStringArrayQueryParam{strArr}, nil
+func NewJsonRpcIntQueryParam(num int) JsonRpcQueryParam {
+	return MustNewJsonRpcQueryParam(num)
 }
 
-func (j jsonRpcStringArrayQueryParam) IsArray() bool {
-	return true
+// NewJsonRpcStringQueryParam creates a string param, special characters will be escaped.
+func NewJsonRpcStringQueryParam(str string) (JsonRpcQueryParam, error) {
+	return NewJsonRpcQueryParam(str)
 }
 
-func (j jsonRpcStringArrayQueryParam) String() string {
-	sb := strings.Builder{}
-	var err error
-	assertErr := func() {
-		utils.PanicIfErr(err, "failed to build query params")
-	}
-
-	_, err = sb.WriteRune('[')
-	assertErr()
-
-	for i, str := range j.value {
-		if i > 0 {
-			_, err = sb.WriteRune(',')
-			assertErr()
-		}
-		_, err = sb.WriteRune('"')
-		assertErr()
-		_, err = sb.WriteString(str)
-		assertErr()
-		_, err = sb.WriteRune('"')
-		assertErr()
+// NewJsonRpcStringArrayQueryParam creates an array of string param, special characters will be escaped.
+func NewJsonRpcStringArrayQueryParam(strArr ...string) (JsonRpcQueryParam, error) {
+	if strArr == nil {
+		strArr = []string{}
 	}
-
-	_, err = sb.WriteRune(']')
-	assertErr()
-
-	return sb.String()
-}
-
-var _ JsonRpcQueryParam = jsonRpcRawQueryParam{}
-
-type jsonRpcRawQueryParam struct {
-	value string
+	return NewJsonRpcQueryParam(strArr)
 }
 
+// NewJsonRpcRawQueryParam creates a param from the given JSON string, as is, without validation.
 func NewJsonRpcRawQueryParam(rawStr string) JsonRpcQueryParam {
-	return jsonRpcRawQueryParam{rawStr}
-}
-
-func (j jsonRpcRawQueryParam) IsArray() bool {
-	return false
-}
-
-func (j jsonRpcRawQueryParam) String() string {
-	return j.value
-}
-
-var _ JsonRpcQueryParam = jsonRpcBoolQueryParam{}
-
-type jsonRpcBoolQueryParam struct {
-	value string
+	return newJsonRpcEncodedQueryParam(rawStr)
 }
 
 func NewJsonRpcBoolQueryParam(value bool) JsonRpcQueryParam {
-	return jsonRpcBoolQueryParam{fmt.Sprintf("%t", value)}
+	return MustNewJsonRpcQueryParam(value)
 }
 
-func (j jsonRpcBoolQueryParam) IsArray() bool {
-	return false
+// NewJsonRpcHexQuantityQueryParam creates a hex-encoded quantity param, like "0x10".
+func NewJsonRpcHexQuantityQueryParam(num *big.Int) JsonRpcQueryParam {
+	return MustNewJsonRpcQueryParam((*hexutil.Big)(num))
 }
 
-func (j jsonRpcBoolQueryParam) String() string {
-	return j.value
+// NewJsonRpcBlockTagQueryParam creates a block param,
+// "latest" if the block number is nil, otherwise the hex-encoded block number.
+func NewJsonRpcBlockTagQueryParam(blockNumber *big.Int) JsonRpcQueryParam {
+	if blockNumber == nil {
+		return MustNewJsonRpcQueryParam("latest")
+	}
+	return NewJsonRpcHexQuantityQueryParam(blockNumber)
 }
 
 const generalEvmQueryTimeout = 3 * time.Second
@@ -226,13 +198,17 @@ func DoEvmRpcQuery(host string, qb JsonRpcQueryBuilder, optionalTimeout time.Dur
 		timeout = time.Second
 	}
 
+	utils.PrintlnStdErr("INF: Querying", host, strings.ReplaceAll(strings.ReplaceAll(qb.String(), "\n", " "), " ", ""))
+
+	return doEvmRpcHttpPost(host, qb.String(), timeout)
+}
+
+func doEvmRpcHttpPost(host, body string, timeout time.Duration) ([]byte, error) {
 	httpClient := http.Client{
 		Timeout: timeout,
 	}
 
-	utils.PrintlnStdErr("INF: Querying", host, strings.ReplaceAll(strings.ReplaceAll(qb.String(), "\n", " "), " ", ""))
-
-	resp, err := httpClient.Post(host, "application/json", bytes.NewBuffer([]byte(qb.String())))
+	resp, err := httpClient.Post(host, "application/json", bytes.NewBuffer([]byte(body)))
 	if err != nil {
 		return nil, err
 	}
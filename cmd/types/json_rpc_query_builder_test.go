@@ -1,8 +1,10 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -98,6 +100,25 @@ func Test_NewJsonRpcQueryBuilder(t *testing.T) {
     "jsonrpc": "2.0"
 }`, qb.(*jsonRpcQueryBuilder).requestId), qb.String())
 	})
+	t.Run("output string must be valid JSON when params contain special characters", func(t *testing.T) {
+		pStr, _ := NewJsonRpcStringQueryParam("a\"b\\c\n")
+		pObj, _ := NewJsonRpcQueryParam(map[string]string{"tracer": "callTracer"})
+		qb := NewJsonRpcQueryBuilder("x_req\"Special", pStr, pObj)
+
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			Id     uint64            `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(qb.String()), &request))
+		require.Equal(t, "x_req\"Special", request.Method)
+		require.Len(t, request.Params, 2)
+		var str string
+		require.NoError(t, json.Unmarshal(request.Params[0], &str))
+		require.Equal(t, "a\"b\\c\n", str)
+		require.Equal(t, `{"tracer":"callTracer"}`, string(request.Params[1]))
+		require.Equal(t, qb.RequestId(), request.Id)
+	})
 	t.Run("request id on json should maintains full-sized when big", func(t *testing.T) {
 		curReqId = math.MaxUint64 - 10
 		qb := NewJsonRpcQueryBuilder("x_req")
@@ -127,8 +148,9 @@ func Test_JsonRpcStringQueryParam(t *testing.T) {
 	var err error
 
 	p, err = NewJsonRpcStringQueryParam("\"16\"")
-	require.Error(t, err)
-	require.Nil(t, p)
+	require.NoError(t, err)
+	require.False(t, p.IsArray())
+	require.Equal(t, `"\"16\""`, p.String(), "double quote must be escaped")
 
 	p, err = NewJsonRpcStringQueryParam("16")
 	require.NoError(t, err)
@@ -140,16 +162,73 @@ func Test_JsonRpcStringArrayQueryParam(t *testing.T) {
 	var p JsonRpcQueryParam
 	var err error
 
-	p, err = NewJsonRpcStringArrayQueryParam("\"16\"")
-	require.Error(t, err)
-	require.Nil(t, p)
-
 	p, err = NewJsonRpcStringArrayQueryParam("15", "\"16\"")
-	require.Error(t, err)
-	require.Nil(t, p)
+	require.NoError(t, err)
+	require.True(t, p.IsArray())
+	require.Equal(t, `["15","\"16\""]`, p.String(), "double quote must be escaped")
 
 	p, err = NewJsonRpcStringArrayQueryParam("15", "16")
 	require.NoError(t, err)
 	require.True(t, p.IsArray())
 	require.Equal(t, `["15","16"]`, p.String())
+
+	p, err = NewJsonRpcStringArrayQueryParam()
+	require.NoError(t, err)
+	require.True(t, p.IsArray())
+	require.Equal(t, `[]`, p.String())
+}
+
+func Test_JsonRpcQueryParam(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		p, err := NewJsonRpcQueryParam(map[string]any{
+			"tracer":  "{ result: function() { return \"x\"; } }",
+			"timeout": "5s",
+		})
+		require.NoError(t, err)
+		require.False(t, p.IsArray())
+		require.Equal(t, `{"timeout":"5s","tracer":"{ result: function() { return \"x\"; } }"}`, p.String())
+	})
+	t.Run("struct", func(t *testing.T) {
+		p, err := NewJsonRpcQueryParam(struct {
+			Address string     `json:"address"`
+			Topics  [][]string `json:"topics"`
+		}{
+			Address: "0x1",
+			Topics:  [][]string{{"0xa", "0xb"}, nil},
+		})
+		require.NoError(t, err)
+		require.False(t, p.IsArray())
+		require.Equal(t, `{"address":"0x1","topics":[["0xa","0xb"],null]}`, p.String())
+	})
+	t.Run("nested array", func(t *testing.T) {
+		p, err := NewJsonRpcQueryParam([][]int{{1, 2}, {3}})
+		require.NoError(t, err)
+		require.True(t, p.IsArray())
+		require.Equal(t, `[[1,2],[3]]`, p.String())
+	})
+	t.Run("not encodable", func(t *testing.T) {
+		p, err := NewJsonRpcQueryParam(make(chan int))
+		require.Error(t, err)
+		require.Nil(t, p)
+	})
+	t.Run("raw", func(t *testing.T) {
+		p := NewJsonRpcRawQueryParam(`[{"a":1}]`)
+		require.True(t, p.IsArray())
+		require.Equal(t, `[{"a":1}]`, p.String())
+	})
+	t.Run("bool", func(t *testing.T) {
+		require.Equal(t, `true`, NewJsonRpcBoolQueryParam(true).String())
+		require.Equal(t, `false`, NewJsonRpcBoolQueryParam(false).String())
+	})
+}
+
+func Test_JsonRpcHexQuantityQueryParam(t *testing.T) {
+	require.Equal(t, `"0x0"`, NewJsonRpcHexQuantityQueryParam(big.NewInt(0)).String())
+	require.Equal(t, `"0x10"`, NewJsonRpcHexQuantityQueryParam(big.NewInt(16)).String())
+	require.False(t, NewJsonRpcHexQuantityQueryParam(big.NewInt(16)).IsArray())
+}
+
+func Test_JsonRpcBlockTagQueryParam(t *testing.T) {
+	require.Equal(t, `"latest"`, NewJsonRpcBlockTagQueryParam(nil).String())
+	require.Equal(t, `"0xff"`, NewJsonRpcBlockTagQueryParam(big.NewInt(255)).String())
 }
@@ -0,0 +1,249 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bcdevtools/devd/v3/cmd/utils"
+	"github.com/bcdevtools/devd/v3/constants"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const keyFileExtension = ".json"
+
+var patternKeyName = regexp.MustCompile(`^[\w.-]+$`)
+
+// ValidateKeyName returns error if the given name is not a valid key name.
+func ValidateKeyName(name string) error {
+	if !patternKeyName.MatchString(name) {
+		return fmt.Errorf("invalid key name [%s], only alphabet, digits, '_', '-' and '.' are allowed", name)
+	}
+	return nil
+}
+
+// GetKeyStoreDirPath returns path of the directory where keys are stored, default is ~/.devd/keys
+func GetKeyStoreDirPath() (string, error) {
+	homeDir, err := utils.GetDevdHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, constants.KEYS_DIR_NAME), nil
+}
+
+// KeyInfo is the public information of a stored key, available without the passphrase.
+type KeyInfo struct {
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+}
+
+// KeyStore manages named EVM keys,
+// each key is stored as a Web3 Secret Storage JSON file named <name>.json in the directory.
+type KeyStore struct {
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// NewKeyStore returns a KeyStore using the given directory, keys are encrypted using the standard scrypt parameters.
+func NewKeyStore(dir string) *KeyStore {
+	return &KeyStore{
+		dir:     dir,
+		scryptN: keystore.StandardScryptN,
+		scryptP: keystore.StandardScryptP,
+	}
+}
+
+func (ks *KeyStore) keyFilePath(name string) string {
+	return filepath.Join(ks.dir, name+keyFileExtension)
+}
+
+// Has returns true if the key with the given name exists.
+func (ks *KeyStore) Has(name string) bool {
+	_, err := os.Stat(ks.keyFilePath(name))
+	return err == nil
+}
+
+// Add encrypts the private key using the passphrase then stores it with the given name.
+// Returns error if the name is taken.
+func (ks *KeyStore) Add(name string, privateKey *ecdsa.PrivateKey, passphrase string) (KeyInfo, error) {
+	if err := ValidateKeyName(name); err != nil {
+		return KeyInfo{}, err
+	}
+	if ks.Has(name) {
+		return KeyInfo{}, fmt.Errorf("key [%s] already exists", name)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return KeyInfo{}, errors.Wrap(err, "failed to generate key id")
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	bz, err := keystore.EncryptKey(key, passphrase, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return KeyInfo{}, errors.Wrap(err, "failed to encrypt key")
+	}
+
+	if err := ks.writeKeyFile(name, bz); err != nil {
+		return KeyInfo{}, err
+	}
+
+	return KeyInfo{
+		Name:    name,
+		Address: key.Address,
+	}, nil
+}
+
+// Import stores the Web3 Secret Storage JSON with the given name, after verifying the passphrase can decrypt it.
+// Returns error if the name is taken.
+func (ks *KeyStore) Import(name string, keyJson []byte, passphrase string) (KeyInfo, error) {
+	if err := ValidateKeyName(name); err != nil {
+		return KeyInfo{}, err
+	}
+	if ks.Has(name) {
+		return KeyInfo{}, fmt.Errorf("key [%s] already exists", name)
+	}
+
+	key, err := keystore.DecryptKey(keyJson, passphrase)
+	if err != nil {
+		return KeyInfo{}, errors.Wrap(err, "failed to decrypt key")
+	}
+
+	if err := ks.writeKeyFile(name, keyJson); err != nil {
+		return KeyInfo{}, err
+	}
+
+	return KeyInfo{
+		Name:    name,
+		Address: key.Address,
+	}, nil
+}
+
+func (ks *KeyStore) writeKeyFile(name string, bz []byte) error {
+	err := os.MkdirAll(ks.dir, 0o700)
+	if err != nil {
+		return errors.Wrap(err, "failed to create keys directory")
+	}
+
+	err = os.WriteFile(ks.keyFilePath(name), bz, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to write key file")
+	}
+
+	return nil
+}
+
+// Get returns the public information of the key with the given name.
+func (ks *KeyStore) Get(name string) (KeyInfo, error) {
+	bz, err := ks.Export(name)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	var keyJson struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(bz, &keyJson); err != nil {
+		return KeyInfo{}, errors.Wrapf(err, "failed to parse key file of [%s]", name)
+	}
+	if !common.IsHexAddress(keyJson.Address) {
+		return KeyInfo{}, fmt.Errorf("invalid address in key file of [%s]", name)
+	}
+
+	return KeyInfo{
+		Name:    name,
+		Address: common.HexToAddress(keyJson.Address),
+	}, nil
+}
+
+// List returns the public information of all keys, sorted by name.
+// Returns empty list if the keys directory does not exist.
+func (ks *KeyStore) List() ([]KeyInfo, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read keys directory")
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keyFileExtension) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), keyFileExtension))
+	}
+	sort.Strings(names)
+
+	keys := make([]KeyInfo, 0, len(names))
+	for _, name := range names {
+		key, err := ks.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Export returns the encrypted Web3 Secret Storage JSON of the key with the given name.
+func (ks *KeyStore) Export(name string) ([]byte, error) {
+	if err := ValidateKeyName(name); err != nil {
+		return nil, err
+	}
+
+	bz, err := os.ReadFile(ks.keyFilePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("key [%s] does not exist", name)
+		}
+		return nil, errors.Wrapf(err, "failed to read key file of [%s]", name)
+	}
+
+	return bz, nil
+}
+
+// Decrypt returns the private key of the key with the given name, decrypted using the passphrase.
+func (ks *KeyStore) Decrypt(name, passphrase string) (*ecdsa.PrivateKey, error) {
+	bz, err := ks.Export(name)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(bz, passphrase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt key [%s]", name)
+	}
+
+	return key.PrivateKey, nil
+}
+
+// Delete removes the key with the given name.
+func (ks *KeyStore) Delete(name string) error {
+	if _, err := ks.Export(name); err != nil {
+		return err
+	}
+
+	if err := os.Remove(ks.keyFilePath(name)); err != nil {
+		return errors.Wrapf(err, "failed to delete key file of [%s]", name)
+	}
+
+	return nil
+}
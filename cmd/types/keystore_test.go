@@ -0,0 +1,110 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyStore(t *testing.T) *KeyStore {
+	ks := NewKeyStore(filepath.Join(t.TempDir(), "keys"))
+	ks.scryptN = keystore.LightScryptN
+	ks.scryptP = keystore.LightScryptP
+	return ks
+}
+
+func TestKeyStore(t *testing.T) {
+	t.Run("add, get, decrypt, list and delete", func(t *testing.T) {
+		ks := newTestKeyStore(t)
+
+		keys, err := ks.List()
+		require.NoError(t, err)
+		require.Empty(t, keys, "not exists directory should returns empty list")
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+		info, err := ks.Add("alice", privateKey, "secret")
+		require.NoError(t, err)
+		require.Equal(t, "alice", info.Name)
+		require.Equal(t, address, info.Address)
+		require.True(t, ks.Has("alice"))
+
+		stat, err := os.Stat(filepath.Join(ks.dir, "alice.json"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), stat.Mode().Perm())
+
+		_, err = ks.Add("alice", privateKey, "secret")
+		require.ErrorContains(t, err, "already exists")
+
+		info, err = ks.Get("alice")
+		require.NoError(t, err)
+		require.Equal(t, address, info.Address)
+
+		decrypted, err := ks.Decrypt("alice", "secret")
+		require.NoError(t, err)
+		require.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(decrypted))
+
+		_, err = ks.Decrypt("alice", "wrong")
+		require.Error(t, err)
+
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		_, err = ks.Add("bob", otherKey, "")
+		require.NoError(t, err)
+
+		keys, err = ks.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		require.Equal(t, "alice", keys[0].Name)
+		require.Equal(t, "bob", keys[1].Name)
+
+		require.NoError(t, ks.Delete("alice"))
+		require.False(t, ks.Has("alice"))
+		require.ErrorContains(t, ks.Delete("alice"), "does not exist")
+
+		_, err = ks.Get("alice")
+		require.ErrorContains(t, err, "does not exist")
+	})
+
+	t.Run("import keystore JSON", func(t *testing.T) {
+		ks := newTestKeyStore(t)
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		_, err = ks.Add("source", privateKey, "secret")
+		require.NoError(t, err)
+
+		keyJson, err := ks.Export("source")
+		require.NoError(t, err)
+
+		_, err = ks.Import("imported", keyJson, "wrong")
+		require.Error(t, err)
+		require.False(t, ks.Has("imported"))
+
+		info, err := ks.Import("imported", keyJson, "secret")
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), info.Address)
+
+		decrypted, err := ks.Decrypt("imported", "secret")
+		require.NoError(t, err)
+		require.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(decrypted))
+	})
+
+	t.Run("reject invalid key name", func(t *testing.T) {
+		ks := newTestKeyStore(t)
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		for _, name := range []string{"", "../escape", "a/b", "with space"} {
+			_, err = ks.Add(name, privateKey, "")
+			require.ErrorContains(t, err, "invalid key name", name)
+		}
+	})
+}
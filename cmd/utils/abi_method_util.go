@@ -0,0 +1,266 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// AbiPackMethodCall converts the string arguments into typed values based on inputs of the method,
+// then ABI-encodes them into call data, with 4 bytes method selector as prefix.
+// See ParseAbiArgumentValue for the accepted formats of the arguments.
+func AbiPackMethodCall(method abi.Method, args []string) ([]byte, error) {
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("method %s requires %d arguments, got %d", method.Sig, len(method.Inputs), len(args))
+	}
+
+	values := make([]any, len(args))
+	for i, input := range method.Inputs {
+		value, err := ParseAbiArgumentValue(input.Type, args[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse argument %d (%s %s)", i, input.Type.String(), input.Name)
+		}
+		values[i] = value
+	}
+
+	packed, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to ABI-encode arguments")
+	}
+
+	return append(append([]byte{}, method.ID...), packed...), nil
+}
+
+// AbiDecodedArgument is a decoded value of an argument, with its name and type.
+type AbiDecodedArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// AbiUnpackMethodOutputs decodes the returned data of the method call into ordered list of outputs,
+// values are converted into JSON-friendly form by AbiValueToJson.
+func AbiUnpackMethodOutputs(method abi.Method, bz []byte) ([]AbiDecodedArgument, error) {
+	values, err := method.Outputs.Unpack(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to ABI-decode outputs")
+	}
+
+	outputs := make([]AbiDecodedArgument, len(values))
+	for i, value := range values {
+		outputs[i] = AbiDecodedArgument{
+			Name:  method.Outputs[i].Name,
+			Type:  method.Outputs[i].Type.String(),
+			Value: AbiValueToJson(value),
+		}
+	}
+	return outputs, nil
+}
+
+// ParseAbiArgumentValue converts the string input into value of the Go type which can be ABI-encoded as the given type.
+// Accepted formats:
+//   - address: 0x or bech32.
+//   - intN/uintN: decimal, hex with 0x prefix, short int like 1e18, 5m.
+//   - bool: true/false.
+//   - bytes/bytesN: hex with or without 0x prefix.
+//   - string: as is.
+//   - arrays: JSON array, like `[1,"0xAddress"]`.
+//   - tuples: JSON array of components in order, or JSON object of components by name.
+func ParseAbiArgumentValue(t abi.Type, input string) (any, error) {
+	value, err := parseAbiArgumentReflectValue(t, input)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+func parseAbiArgumentReflectValue(t abi.Type, input string) (reflect.Value, error) {
+	goType := t.GetType()
+
+	switch t.T {
+	case abi.AddressTy:
+		addrs, err := GetEvmAddressFromAnyFormatAddress(strings.TrimSpace(input))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(addrs[0]), nil
+
+	case abi.IntTy, abi.UintTy:
+		num, err := ReadShortIntOrHex(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.T == abi.UintTy {
+			if num.Sign() < 0 || num.BitLen() > t.Size {
+				return reflect.Value{}, fmt.Errorf("%s overflows %s", num, t.String())
+			}
+		} else {
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+			if num.Cmp(limit) >= 0 || num.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return reflect.Value{}, fmt.Errorf("%s overflows %s", num, t.String())
+			}
+		}
+		if goType.Kind() == reflect.Ptr { // *big.Int
+			return reflect.ValueOf(num), nil
+		}
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(num.Uint64()).Convert(goType), nil
+		}
+		return reflect.ValueOf(num.Int64()).Convert(goType), nil
+
+	case abi.BoolTy:
+		b, err := strconv.ParseBool(strings.TrimSpace(input))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bool: %s", input)
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.StringTy:
+		return reflect.ValueOf(input), nil
+
+	case abi.BytesTy:
+		bz, err := decodeHexInput(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(bz), nil
+
+	case abi.FixedBytesTy:
+		bz, err := decodeHexInput(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(bz) != t.Size {
+			return reflect.Value{}, fmt.Errorf("%s requires %d bytes, got %d", t.String(), t.Size, len(bz))
+		}
+		value := reflect.New(goType).Elem()
+		reflect.Copy(value, reflect.ValueOf(bz))
+		return value, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		elements, err := splitJsonArrayInput(input)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(goType, len(elements), len(elements))
+		} else {
+			if len(elements) != t.Size {
+				return reflect.Value{}, fmt.Errorf("%s requires %d elements, got %d", t.String(), t.Size, len(elements))
+			}
+			value = reflect.New(goType).Elem()
+		}
+
+		for i, element := range elements {
+			elementValue, err := parseAbiArgumentReflectValue(*t.Elem, element)
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "element %d", i)
+			}
+			value.Index(i).Set(elementValue)
+		}
+		return value, nil
+
+	case abi.TupleTy:
+		components, err := splitJsonTupleInput(input, t.TupleRawNames)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		value := reflect.New(goType).Elem()
+		for i, elem := range t.TupleElems {
+			componentValue, err := parseAbiArgumentReflectValue(*elem, components[i])
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "component %s", t.TupleRawNames[i])
+			}
+			value.Field(i).Set(componentValue)
+		}
+		return value, nil
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t.String())
+	}
+}
+
+func decodeHexInput(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "0x") && !strings.HasPrefix(input, "0X") {
+		input = "0x" + input
+	}
+	bz, err := hexutil.Decode(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid hex %s", input)
+	}
+	return bz, nil
+}
+
+// splitJsonArrayInput splits JSON array input into string form of each element.
+func splitJsonArrayInput(input string) ([]string, error) {
+	var elements []any
+	if err := unmarshalJsonInput(input, &elements); err != nil {
+		return nil, errors.Wrapf(err, "require JSON array: %s", input)
+	}
+	return jsonValuesToStrings(elements)
+}
+
+// splitJsonTupleInput splits JSON array or JSON object input into string form of each tuple component, by order.
+func splitJsonTupleInput(input string, names []string) ([]string, error) {
+	var elements []any
+	if err := unmarshalJsonInput(input, &elements); err == nil {
+		if len(elements) != len(names) {
+			return nil, fmt.Errorf("tuple requires %d components, got %d", len(names), len(elements))
+		}
+		return jsonValuesToStrings(elements)
+	}
+
+	var obj map[string]any
+	if err := unmarshalJsonInput(input, &obj); err != nil {
+		return nil, errors.Wrapf(err, "require JSON array or object: %s", input)
+	}
+
+	elements = make([]any, len(names))
+	for i, name := range names {
+		value, found := obj[name]
+		if !found {
+			return nil, fmt.Errorf("missing tuple component %s", name)
+		}
+		elements[i] = value
+	}
+	return jsonValuesToStrings(elements)
+}
+
+func unmarshalJsonInput(input string, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(input)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func jsonValuesToStrings(values []any) ([]string, error) {
+	result := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			result[i] = v
+		case json.Number:
+			result[i] = v.String()
+		case bool:
+			result[i] = strconv.FormatBool(v)
+		default:
+			bz, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = string(bz)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMethodSignature(t *testing.T) {
+	tests := []struct {
+		signature   string
+		wantSig     string
+		wantID      string
+		wantInputs  []string
+		wantOutputs []string
+	}{
+		{
+			signature:   "balanceOf(address)(uint256)",
+			wantSig:     "balanceOf(address)",
+			wantID:      "0x70a08231",
+			wantInputs:  []string{"arg0"},
+			wantOutputs: []string{"out0"},
+		},
+		{
+			signature:   "function balanceOf(address account) external view returns (uint256 balance);",
+			wantSig:     "balanceOf(address)",
+			wantID:      "0x70a08231",
+			wantInputs:  []string{"account"},
+			wantOutputs: []string{"balance"},
+		},
+		{
+			signature:   "transfer(address recipient, uint amount)",
+			wantSig:     "transfer(address,uint256)",
+			wantID:      "0xa9059cbb",
+			wantInputs:  []string{"recipient", "amount"},
+			wantOutputs: []string{},
+		},
+		{
+			signature:   "proposeRepeated((address target, bytes data)[] calls, uint256)(bool, (uint8,string))",
+			wantSig:     "proposeRepeated((address,bytes)[],uint256)",
+			wantID:      "0x013a652d",
+			wantInputs:  []string{"calls", "arg1"},
+			wantOutputs: []string{"out0", "out1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.signature, func(t *testing.T) {
+			method, err := ParseMethodSignature(tt.signature)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSig, method.Sig)
+			require.Equal(t, tt.wantID, hexutil.Encode(method.ID))
+
+			inputs := make([]string, 0)
+			for _, input := range method.Inputs {
+				inputs = append(inputs, input.Name)
+			}
+			require.Equal(t, tt.wantInputs, inputs)
+
+			outputs := make([]string, 0)
+			for _, output := range method.Outputs {
+				outputs = append(outputs, output.Name)
+			}
+			require.Equal(t, tt.wantOutputs, outputs)
+		})
+	}
+
+	for _, signature := range []string{"balanceOf", "balanceOf(address", "balanceOf(address)(uint256", "balanceOf(address indexed a)"} {
+		_, err := ParseMethodSignature(signature)
+		require.Error(t, err, signature)
+	}
+}
+
+func TestAbiPackMethodCall(t *testing.T) {
+	t.Run("simple types", func(t *testing.T) {
+		method, err := ParseMethodSignature("transfer(address,uint256)")
+		require.NoError(t, err)
+
+		callData, err := AbiPackMethodCall(method, []string{"0x1111111111111111111111111111111111111111", "1e18"})
+		require.NoError(t, err)
+		require.Equal(t, "0xa9059cbb"+
+			"0000000000000000000000001111111111111111111111111111111111111111"+
+			"0000000000000000000000000000000000000000000000000de0b6b3a7640000", hexutil.Encode(callData))
+
+		_, err = AbiPackMethodCall(method, []string{"0x1111111111111111111111111111111111111111"})
+		require.Error(t, err, "must reject missing argument")
+
+		_, err = AbiPackMethodCall(method, []string{"0x1111111111111111111111111111111111111111", "-1"})
+		require.Error(t, err, "must reject negative uint")
+	})
+
+	t.Run("complex types", func(t *testing.T) {
+		method, err := ParseMethodSignature("f(uint8,int64,bool,bytes,bytes4,string,address[],(uint256 a,string b)[2])")
+		require.NoError(t, err)
+
+		callData, err := AbiPackMethodCall(method, []string{
+			"255",
+			"-5",
+			"true",
+			"0x0102",
+			"a9059cbb",
+			"hello",
+			`["0x1111111111111111111111111111111111111111","0x2222222222222222222222222222222222222222"]`,
+			`[[1,"x"],{"a":"0x2","b":"y"}]`,
+		})
+		require.NoError(t, err)
+
+		values, err := method.Inputs.Unpack(callData[4:])
+		require.NoError(t, err)
+		require.Equal(t, uint8(255), values[0])
+		require.Equal(t, int64(-5), values[1])
+		require.Equal(t, true, values[2])
+		require.Equal(t, []byte{1, 2}, values[3])
+		require.Equal(t, [4]byte{0xa9, 0x05, 0x9c, 0xbb}, values[4])
+		require.Equal(t, "hello", values[5])
+		require.Equal(t, []common.Address{
+			common.HexToAddress("0x1111111111111111111111111111111111111111"),
+			common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		}, values[6])
+		require.Equal(t, []any{
+			map[string]any{"a": "1", "b": "x"},
+			map[string]any{"a": "2", "b": "y"},
+		}, AbiValueToJson(values[7]))
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		for _, tt := range []struct {
+			typ   string
+			input string
+		}{
+			{"uint8", "256"},
+			{"int8", "128"},
+			{"int8", "-129"},
+			{"bool", "yes"},
+			{"bytes4", "0x01"},
+			{"bytes", "0xz"},
+			{"address", "0x1"},
+			{"uint256[2]", "[1]"},
+			{"uint256[]", "1"},
+			{"(uint256,bool)", "[1]"},
+		} {
+			abiType, err := abi.NewType(tt.typ, "", nil)
+			if err != nil {
+				marshaling, errM := toAbiArgumentMarshaling("x", tt.typ)
+				require.NoError(t, errM)
+				abiType, err = abi.NewType(marshaling.Type, "", marshaling.Components)
+			}
+			require.NoError(t, err)
+
+			_, err = ParseAbiArgumentValue(abiType, tt.input)
+			require.Error(t, err, "%s %s", tt.typ, tt.input)
+		}
+	})
+}
+
+func TestAbiUnpackMethodOutputs(t *testing.T) {
+	method, err := ParseMethodSignature("f()(uint256 balance, string, address)")
+	require.NoError(t, err)
+
+	bz, err := method.Outputs.Pack(big.NewInt(1000), "hello", common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	require.NoError(t, err)
+
+	outputs, err := AbiUnpackMethodOutputs(method, bz)
+	require.NoError(t, err)
+	require.Equal(t, []AbiDecodedArgument{
+		{Name: "balance", Type: "uint256", Value: "1000"},
+		{Name: "out1", Type: "string", Value: "hello"},
+		{Name: "out2", Type: "address", Value: "0x1111111111111111111111111111111111111111"},
+	}, outputs)
+
+	_, err = AbiUnpackMethodOutputs(method, []byte{0x1})
+	require.Error(t, err)
+}
@@ -0,0 +1,411 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ParseEventSignature parses the Solidity event interface like `Transfer(address indexed from, address indexed to, uint256 value)`
+// into an ABI event. Arguments without name will be named as `arg0`, `arg1`,...
+func ParseEventSignature(signature string) (abi.Event, error) {
+	name, args, err := parseSignature(signature, true, "arg")
+	if err != nil {
+		return abi.Event{}, err
+	}
+	return abi.NewEvent(name, name, false, args), nil
+}
+
+// ParseMethodSignature parses the Solidity method interface with optional outputs into an ABI method.
+// Accepted formats:
+//   - `balanceOf(address)(uint256)`
+//   - `function balanceOf(address account) external view returns (uint256 balance)`
+//
+// Inputs without name will be named as `arg0`, `arg1`,... and outputs without name will be named as `out0`, `out1`,...
+func ParseMethodSignature(signature string) (abi.Method, error) {
+	signature = strings.TrimSpace(signature)
+
+	openingInputs := strings.Index(signature, "(")
+	if openingInputs < 0 {
+		return abi.Method{}, fmt.Errorf("invalid EVM method interface, require format: `methodName(...)`: %s", signature)
+	}
+	closingInputs := findClosingParenthesis(signature[openingInputs:])
+	if closingInputs < 0 {
+		return abi.Method{}, fmt.Errorf("unbalanced parenthesis: %s", signature)
+	}
+	closingInputs += openingInputs
+
+	inputsPart := signature[:closingInputs+1]
+
+	var outputsPart string
+	remaining := strings.TrimSpace(signature[closingInputs+1:])
+	if loc := regexp.MustCompile(`\breturns\s*\(`).FindStringIndex(remaining); loc != nil {
+		remaining = remaining[loc[1]-1:]
+	}
+	if strings.HasPrefix(remaining, "(") {
+		closingOutputs := findClosingParenthesis(remaining)
+		if closingOutputs < 0 {
+			return abi.Method{}, fmt.Errorf("unbalanced parenthesis: %s", signature)
+		}
+		outputsPart = remaining[1:closingOutputs]
+	}
+
+	name, inputs, err := parseSignature(inputsPart, false, "arg")
+	if err != nil {
+		return abi.Method{}, err
+	}
+
+	outputs, err := parseSignatureArguments(outputsPart, false, "out")
+	if err != nil {
+		return abi.Method{}, errors.Wrapf(err, "failed to parse outputs of %s", name)
+	}
+
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, inputs, outputs)
+
+	// ensure the selector is consistent with `convert solc-sig`
+	_4BytesSig, _, _, err := GetSignatureFromInterface(method.Sig)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	if hexutil.Encode(method.ID) != _4BytesSig {
+		return abi.Method{}, fmt.Errorf("signature mismatch %s != %s, unsupported method interface: %s", hexutil.Encode(method.ID), _4BytesSig, signature)
+	}
+
+	return method, nil
+}
+
+// parseSignature parses the normalized form of Solidity method/event interface into name and arguments.
+func parseSignature(signature string, allowIndexed bool, unnamedPrefix string) (name string, args abi.Arguments, err error) {
+	normalized := NormalizeEvmEventOrMethodInterface(signature)
+	if !regexp.MustCompile(`^\w+\(.*\)$`).MatchString(normalized) {
+		err = fmt.Errorf("invalid EVM method/event interface, require format: `name(...)`: %s", signature)
+		return
+	}
+
+	spl := strings.SplitN(normalized[:len(normalized)-1], "(", 2)
+	name = spl[0]
+
+	args, err = parseSignatureArguments(spl[1], allowIndexed, unnamedPrefix)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse arguments of %s", name)
+	}
+	return
+}
+
+// parseSignatureArguments parses comma separated arguments like `address indexed from, (uint256,string)[] data`.
+func parseSignatureArguments(argsPart string, allowIndexed bool, unnamedPrefix string) (abi.Arguments, error) {
+	args := abi.Arguments{}
+	for i, arg := range splitTopLevelSignatureArguments(argsPart) {
+		typeStr, argName, indexed, err := splitSignatureArgument(arg)
+		if err != nil {
+			return nil, err
+		}
+		if indexed && !allowIndexed {
+			return nil, fmt.Errorf("'indexed' keyword is not allowed: %s", arg)
+		}
+		if argName == "" {
+			argName = fmt.Sprintf("%s%d", unnamedPrefix, i)
+		}
+
+		marshaling, err := toAbiArgumentMarshaling(argName, typeStr)
+		if err != nil {
+			return nil, err
+		}
+
+		abiType, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid type %s", typeStr)
+		}
+
+		args = append(args, abi.Argument{
+			Name:    argName,
+			Type:    abiType,
+			Indexed: indexed,
+		})
+	}
+	return args, nil
+}
+
+// splitTopLevelSignatureArguments splits the arguments by comma, ignoring commas inside tuples.
+func splitTopLevelSignatureArguments(argsPart string) []string {
+	argsPart = strings.TrimSpace(argsPart)
+	if argsPart == "" {
+		return nil
+	}
+
+	var args []string
+	var level int
+	var start int
+	for i, c := range argsPart {
+		switch c {
+		case '(':
+			level++
+		case ')':
+			level--
+		case ',':
+			if level == 0 {
+				args = append(args, strings.TrimSpace(argsPart[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(args, strings.TrimSpace(argsPart[start:]))
+}
+
+// splitSignatureArgument splits argument like `address indexed from` into type, name and indexed flag.
+func splitSignatureArgument(arg string) (typeStr, name string, indexed bool, err error) {
+	var endOfType int
+	if strings.HasPrefix(arg, "(") {
+		closing := findClosingParenthesis(arg)
+		if closing < 0 {
+			err = fmt.Errorf("unbalanced parenthesis: %s", arg)
+			return
+		}
+		endOfType = closing + strings.IndexAny(arg[closing:]+" ", " ")
+	} else {
+		endOfType = strings.IndexAny(arg+" ", " ")
+	}
+
+	typeStr = normalizeSignatureType(arg[:endOfType])
+
+	for _, field := range strings.Fields(arg[endOfType:]) {
+		switch field {
+		case "indexed":
+			indexed = true
+		case "memory", "calldata", "storage", "payable":
+			// ignore
+		default:
+			if name != "" {
+				err = fmt.Errorf("invalid argument: %s", arg)
+				return
+			}
+			name = field
+		}
+	}
+	return
+}
+
+// normalizeSignatureType converts the type aliases into canonical form, eg: `uint` => `uint256`.
+func normalizeSignatureType(typeStr string) string {
+	return regexp.MustCompile(`\b(u?int)\b(\[|$|,|\)|\s)`).ReplaceAllString(typeStr, "${1}256$2")
+}
+
+func findClosingParenthesis(str string) int {
+	var level int
+	for i, c := range str {
+		switch c {
+		case '(':
+			level++
+		case ')':
+			level--
+			if level == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// toAbiArgumentMarshaling converts the type into ABI argument marshaling form, tuples are converted into components.
+func toAbiArgumentMarshaling(name, typeStr string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(typeStr, "(") {
+		return abi.ArgumentMarshaling{
+			Name: name,
+			Type: typeStr,
+		}, nil
+	}
+
+	closing := findClosingParenthesis(typeStr)
+	if closing < 0 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced parenthesis: %s", typeStr)
+	}
+
+	var components []abi.ArgumentMarshaling
+	for i, component := range splitTopLevelSignatureArguments(typeStr[1:closing]) {
+		componentType, componentName, _, err := splitSignatureArgument(component)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		if componentName == "" {
+			componentName = fmt.Sprintf("field%d", i)
+		}
+
+		marshaling, err := toAbiArgumentMarshaling(componentName, componentType)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		components = append(components, marshaling)
+	}
+
+	return abi.ArgumentMarshaling{
+		Name:       name,
+		Type:       "tuple" + typeStr[closing+1:],
+		Components: components,
+	}, nil
+}
+
+// ReadAbiFile reads the ABI from the given JSON file.
+// Both ABI array and artifact which contains the `abi` field (Hardhat, Foundry,...) are accepted.
+func ReadAbiFile(file string) (abi.ABI, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return abi.ABI{}, errors.Wrap(err, "failed to read ABI file")
+	}
+
+	var artifact struct {
+		Abi json.RawMessage `json:"abi"`
+	}
+	if err := json.Unmarshal(bz, &artifact); err == nil && len(artifact.Abi) > 0 {
+		bz = artifact.Abi
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(bz)))
+	if err != nil {
+		return abi.ABI{}, errors.Wrap(err, "failed to parse ABI file")
+	}
+
+	return parsed, nil
+}
+
+// DecodeEvmLog decodes the indexed and non-indexed arguments of the event log into a map of argument name => value.
+// Indexed arguments of dynamic types are kept as topic hash because the original value is not recoverable.
+// When the event has no indexed argument, like the one parsed from `Transfer(address,address,uint256)`,
+// the leading arguments are treated as indexed, one per topic after the event signature.
+// Values are converted into JSON-friendly form by AbiValueToJson.
+func DecodeEvmLog(event abi.Event, topics []common.Hash, data []byte) (map[string]any, error) {
+	if !event.Anonymous {
+		if len(topics) < 1 || topics[0] != event.ID {
+			return nil, fmt.Errorf("event signature mismatch")
+		}
+		topics = topics[1:]
+	}
+
+	event = inferIndexedInputsOfEvent(event, len(topics))
+
+	decoded := make(map[string]any)
+
+	var topicIdx int
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topicIdx >= len(topics) {
+			return nil, fmt.Errorf("missing topic for indexed argument %s", input.Name)
+		}
+		topic := topics[topicIdx]
+		topicIdx++
+
+		switch input.Type.T {
+		case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+			decoded[input.Name] = topic.Hex()
+		default:
+			values := make(map[string]any)
+			if err := abi.ParseTopicsIntoMap(values, abi.Arguments{input}, []common.Hash{topic}); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode indexed argument %s", input.Name)
+			}
+			decoded[input.Name] = AbiValueToJson(values[input.Name])
+		}
+	}
+
+	nonIndexed := event.Inputs.NonIndexed()
+	if len(nonIndexed) > 0 {
+		values := make(map[string]any)
+		if err := nonIndexed.UnpackIntoMap(values, data); err != nil {
+			return nil, errors.Wrap(err, "failed to decode non-indexed arguments")
+		}
+		for name, value := range values {
+			decoded[name] = AbiValueToJson(value)
+		}
+	}
+
+	return decoded, nil
+}
+
+// inferIndexedInputsOfEvent marks the first topicsCount inputs as indexed if none of the inputs is indexed,
+// the event is returned as is if it has any indexed input or the number of topics exceeds the number of inputs.
+func inferIndexedInputsOfEvent(event abi.Event, topicsCount int) abi.Event {
+	if topicsCount < 1 || topicsCount > len(event.Inputs) {
+		return event
+	}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			return event
+		}
+	}
+
+	inputs := make(abi.Arguments, len(event.Inputs))
+	copy(inputs, event.Inputs)
+	for i := 0; i < topicsCount; i++ {
+		inputs[i].Indexed = true
+	}
+	event.Inputs = inputs
+	return event
+}
+
+// AbiValueToJson converts value decoded by ABI into JSON-friendly form:
+// numbers are converted into decimal string, addresses, hashes and bytes are converted into hex string,
+// tuples are converted into map.
+func AbiValueToJson(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case string, bool:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return fmt.Sprintf("%d", rv.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return fmt.Sprintf("%d", rv.Uint())
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bz := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(bz), rv)
+			return hexutil.Encode(bz)
+		}
+		fallthrough
+	case reflect.Slice:
+		list := make([]any, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			list[i] = AbiValueToJson(rv.Index(i).Interface())
+		}
+		return list
+	case reflect.Struct:
+		obj := make(map[string]any)
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			obj[name] = AbiValueToJson(rv.Field(i).Interface())
+		}
+		return obj
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return AbiValueToJson(rv.Elem().Interface())
+	default:
+		return value
+	}
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventSignature(t *testing.T) {
+	t.Run("with names and indexed", func(t *testing.T) {
+		event, err := ParseEventSignature("event Transfer(address indexed from, address indexed to, uint256 value);")
+		require.NoError(t, err)
+		require.Equal(t, "Transfer", event.Name)
+		require.Equal(t, "Transfer(address,address,uint256)", event.Sig)
+		require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", event.ID.Hex())
+		require.Len(t, event.Inputs, 3)
+		require.Equal(t, "from", event.Inputs[0].Name)
+		require.True(t, event.Inputs[0].Indexed)
+		require.Equal(t, "to", event.Inputs[1].Name)
+		require.True(t, event.Inputs[1].Indexed)
+		require.Equal(t, "value", event.Inputs[2].Name)
+		require.False(t, event.Inputs[2].Indexed)
+	})
+
+	t.Run("without names", func(t *testing.T) {
+		event, err := ParseEventSignature("Transfer(address,address,uint)")
+		require.NoError(t, err)
+		require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", event.ID.Hex(), "uint must be treated as uint256")
+		require.Equal(t, "arg0", event.Inputs[0].Name)
+		require.Equal(t, "arg2", event.Inputs[2].Name)
+	})
+
+	t.Run("tuple", func(t *testing.T) {
+		event, err := ParseEventSignature("Updated((address owner, uint256[] ids)[] indexed items, (string,bool) info)")
+		require.NoError(t, err)
+		require.Equal(t, "Updated((address,uint256[])[],(string,bool))", event.Sig)
+		require.True(t, event.Inputs[0].Indexed)
+		require.Equal(t, abi.SliceTy, event.Inputs[0].Type.T)
+		require.Equal(t, abi.TupleTy, event.Inputs[1].Type.T)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, signature := range []string{"Transfer", "Transfer(address,foo)", "Transfer((address,uint256)"} {
+			_, err := ParseEventSignature(signature)
+			require.Error(t, err, signature)
+		}
+	})
+}
+
+func TestDecodeEvmLog(t *testing.T) {
+	event, err := ParseEventSignature("Transfer(address indexed from, address indexed to, uint256 value)")
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := common.BigToHash(big.NewInt(1000)).Bytes()
+
+	decoded, err := DecodeEvmLog(event, []common.Hash{
+		event.ID,
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}, data)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"from":  from.Hex(),
+		"to":    to.Hex(),
+		"value": "1000",
+	}, decoded)
+
+	_, err = DecodeEvmLog(event, []common.Hash{common.HexToHash("0x1")}, data)
+	require.Error(t, err, "must reject log of another event")
+
+	_, err = DecodeEvmLog(event, []common.Hash{event.ID}, data)
+	require.Error(t, err, "must reject log missing indexed topics")
+}
+
+func TestDecodeEvmLogOfSignatureWithoutIndexed(t *testing.T) {
+	event, err := ParseEventSignature("Transfer(address,address,uint256)")
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	// ERC-20 Transfer log: 3 topics and 32 bytes data
+	topics := []common.Hash{
+		common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}
+	data := common.BigToHash(big.NewInt(1000)).Bytes()
+
+	decoded, err := DecodeEvmLog(event, topics, data)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"arg0": from.Hex(),
+		"arg1": to.Hex(),
+		"arg2": "1000",
+	}, decoded)
+
+	for _, input := range event.Inputs {
+		require.False(t, input.Indexed, "the parsed event must not be modified")
+	}
+}
+
+func TestAbiValueToJson(t *testing.T) {
+	require.Equal(t, "100", AbiValueToJson(big.NewInt(100)))
+	require.Equal(t, "8", AbiValueToJson(uint8(8)))
+	require.Equal(t, "-8", AbiValueToJson(int32(-8)))
+	require.Equal(t, true, AbiValueToJson(true))
+	require.Equal(t, "0x0102", AbiValueToJson([]byte{1, 2}))
+	require.Equal(t, "0x0102", AbiValueToJson([2]byte{1, 2}))
+	require.Equal(t, []any{"1", "2"}, AbiValueToJson([]*big.Int{big.NewInt(1), big.NewInt(2)}))
+	require.Equal(t, map[string]any{
+		"owner": "0x1111111111111111111111111111111111111111",
+		"ids":   []any{"1"},
+	}, AbiValueToJson(struct {
+		Owner common.Address `json:"owner"`
+		Ids   []*big.Int     `json:"ids"`
+	}{
+		Owner: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Ids:   []*big.Int{big.NewInt(1)},
+	}))
+}
+
+func TestReadAbiFile(t *testing.T) {
+	const abiJson = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+	dir := t.TempDir()
+
+	abiFile := filepath.Join(dir, "erc20.abi.json")
+	require.NoError(t, os.WriteFile(abiFile, []byte(abiJson), 0o600))
+
+	artifactFile := filepath.Join(dir, "erc20.json")
+	require.NoError(t, os.WriteFile(artifactFile, []byte(`{"contractName":"ERC20","abi":`+abiJson+`}`), 0o600))
+
+	for _, file := range []string{abiFile, artifactFile} {
+		parsed, err := ReadAbiFile(file)
+		require.NoError(t, err, file)
+		require.Contains(t, parsed.Events, "Transfer", file)
+	}
+
+	_, err := ReadAbiFile(filepath.Join(dir, "not-exists.json"))
+	require.Error(t, err)
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
@@ -43,3 +45,58 @@ func init() {
 		},
 	}
 }
+
+var (
+	// revertSelectorError is the selector of `Error(string)`, used by `revert("reason")` and `require(cond, "reason")`
+	revertSelectorError = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// revertSelectorPanic is the selector of `Panic(uint256)`, used by compiler-inserted checks like overflow or division by zero
+	revertSelectorPanic = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// TryDecodeRevertReason decodes the revert data returned by EVM execution.
+// Supports `Error(string)` and `Panic(uint256)`, returns false if the data is not in one of the formats.
+func TryDecodeRevertReason(bz []byte) (reason string, ok bool) {
+	if len(bz) < 4 {
+		return "", false
+	}
+
+	selector, data := bz[:4], bz[4:]
+	switch {
+	case bytes.Equal(selector, revertSelectorError):
+		if len(data)%32 != 0 {
+			return "", false
+		}
+		msg, err := AbiDecodeString(data)
+		if err != nil || msg == "" {
+			return "", false
+		}
+		return msg, true
+	case bytes.Equal(selector, revertSelectorPanic):
+		if len(data) != 32 {
+			return "", false
+		}
+		code := new(big.Int).SetBytes(data)
+		if code.IsUint64() {
+			if description, found := panicCodeDescriptions[code.Uint64()]; found {
+				return fmt.Sprintf("panic 0x%02x: %s", code.Uint64(), description), true
+			}
+		}
+		return fmt.Sprintf("panic 0x%s", code.Text(16)), true
+	default:
+		return "", false
+	}
+}
+
+// panicCodeDescriptions describes the codes of `Panic(uint256)`, see https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicCodeDescriptions = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to zero-initialized internal function",
+}
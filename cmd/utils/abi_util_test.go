@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryDecodeRevertReason(t *testing.T) {
+	errorData := func(msg string) []byte {
+		bz, err := AbiEncodeString(msg)
+		require.NoError(t, err)
+		return append([]byte{0x08, 0xc3, 0x79, 0xa0}, bz...)
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		wantReason string
+		wantOk     bool
+	}{
+		{
+			name:       "Error(string)",
+			data:       errorData("ERC20: transfer amount exceeds balance"),
+			wantReason: "ERC20: transfer amount exceeds balance",
+			wantOk:     true,
+		},
+		{
+			name:   "Error(string) with empty message",
+			data:   errorData(""),
+			wantOk: false,
+		},
+		{
+			name:       "Panic(uint256) overflow",
+			data:       hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000011"),
+			wantReason: "panic 0x11: arithmetic underflow or overflow",
+			wantOk:     true,
+		},
+		{
+			name:       "Panic(uint256) unknown code",
+			data:       hexutil.MustDecode("0x4e487b7100000000000000000000000000000000000000000000000000000000000000ff"),
+			wantReason: "panic 0xff",
+			wantOk:     true,
+		},
+		{
+			name:   "custom error",
+			data:   hexutil.MustDecode("0xe450d38c0000000000000000000000000000000000000000000000000000000000000001"),
+			wantOk: false,
+		},
+		{
+			name:   "malformed Error(string)",
+			data:   hexutil.MustDecode("0x08c379a00000"),
+			wantOk: false,
+		},
+		{
+			name:   "empty",
+			data:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := TryDecodeRevertReason(tt.data)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
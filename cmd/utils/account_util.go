@@ -2,21 +2,60 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	cosmoshd "github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/go-bip39"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// FromSecretToPrivateKey converts the secret, which is a hex private key or a 12/24 words mnemonic, into private key.
+// Mnemonic is derived using the given BIP39 passphrase and HD path.
+func FromSecretToPrivateKey(secret, bip39Passphrase string, hdPath accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	secret = strings.TrimSpace(secret)
+
+	if regexp.MustCompile(`^(0x)?[a-fA-F\d]{64}$`).MatchString(secret) {
+		pKeyBytes, err := hexutil.Decode("0x" + strings.TrimPrefix(secret, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key")
+		}
+
+		ecdsaPrivateKey, err := crypto.ToECDSA(pKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert private key to ECDSA")
+		}
+
+		return ecdsaPrivateKey, nil
+	}
+
+	if mnemonicCount := len(strings.Fields(secret)); mnemonicCount == 12 || mnemonicCount == 24 {
+		return FromMnemonicToPrivateKeyWithHdPath(secret, bip39Passphrase, hdPath)
+	}
+
+	return nil, fmt.Errorf("invalid secret key format, require private key or 12/24 words mnemonic")
+}
+
+// DefaultHdPath is the HD derivation path used when no custom path is provided, coin type 60, account 0, index 0.
+var DefaultHdPath = cosmoshd.CreateHDPath(60, 0, 0).String()
+
+// FromMnemonicToPrivateKey derives the private key from the mnemonic using the DefaultHdPath.
 func FromMnemonicToPrivateKey(mnemonic, password string) (*ecdsa.PrivateKey, error) {
-	hdPathStr := cosmoshd.CreateHDPath(60, 0, 0).String()
-	hdPath, err := accounts.ParseDerivationPath(hdPathStr)
+	hdPath, err := accounts.ParseDerivationPath(DefaultHdPath)
 	if err != nil {
 		return nil, err
 	}
 
+	return FromMnemonicToPrivateKeyWithHdPath(mnemonic, password, hdPath)
+}
+
+// FromMnemonicToPrivateKeyWithHdPath derives the private key from the mnemonic and BIP39 passphrase using the given HD path.
+func FromMnemonicToPrivateKeyWithHdPath(mnemonic, password string, hdPath accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, password)
 	if err != nil {
 		return nil, err
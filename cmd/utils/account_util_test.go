@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMnemonicToPrivateKeyWithHdPath(t *testing.T) {
+	const mnemonic = "test test test test test test test test test test test junk"
+
+	tests := []struct {
+		name            string
+		hdPath          string
+		bip39Passphrase string
+		wantAddress     string
+	}{
+		{
+			name:        "default path",
+			hdPath:      DefaultHdPath,
+			wantAddress: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		{
+			name:        "index 1",
+			hdPath:      "m/44'/60'/0'/0/1",
+			wantAddress: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdPath, err := accounts.ParseDerivationPath(tt.hdPath)
+			require.NoError(t, err)
+
+			privateKey, err := FromMnemonicToPrivateKeyWithHdPath(mnemonic, tt.bip39Passphrase, hdPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantAddress, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+		})
+	}
+
+	t.Run("coin type changes the derived key", func(t *testing.T) {
+		defaultHdPath, err := accounts.ParseDerivationPath(DefaultHdPath)
+		require.NoError(t, err)
+		cosmosHdPath, err := accounts.ParseDerivationPath("m/44'/118'/0'/0/0")
+		require.NoError(t, err)
+
+		ethKey, err := FromMnemonicToPrivateKeyWithHdPath(mnemonic, "", defaultHdPath)
+		require.NoError(t, err)
+		cosmosKey, err := FromMnemonicToPrivateKeyWithHdPath(mnemonic, "", cosmosHdPath)
+		require.NoError(t, err)
+		require.NotEqual(t, crypto.FromECDSA(ethKey), crypto.FromECDSA(cosmosKey))
+	})
+
+	t.Run("BIP39 passphrase changes the derived key", func(t *testing.T) {
+		hdPath, err := accounts.ParseDerivationPath(DefaultHdPath)
+		require.NoError(t, err)
+
+		withoutPassphrase, err := FromMnemonicToPrivateKeyWithHdPath(mnemonic, "", hdPath)
+		require.NoError(t, err)
+		withPassphrase, err := FromMnemonicToPrivateKeyWithHdPath(mnemonic, "secret", hdPath)
+		require.NoError(t, err)
+		require.NotEqual(t, crypto.FromECDSA(withoutPassphrase), crypto.FromECDSA(withPassphrase))
+	})
+
+	t.Run("invalid mnemonic", func(t *testing.T) {
+		hdPath, err := accounts.ParseDerivationPath(DefaultHdPath)
+		require.NoError(t, err)
+
+		_, err = FromMnemonicToPrivateKeyWithHdPath("test test test test test test test test test test test test", "", hdPath)
+		require.Error(t, err)
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -44,3 +46,22 @@ func GetEvmAddressFromAnyFormatAddress(addrs ...string) (evmAddrs []common.Addre
 
 	return
 }
+
+// GetConsensusAddressFromPubKey returns the consensus address of the validator consensus public key,
+// the type is the type URL of the key, eg: /cosmos.crypto.ed25519.PubKey
+func GetConsensusAddressFromPubKey(typeUrl string, key []byte) ([]byte, error) {
+	switch typeUrl {
+	case "/cosmos.crypto.ed25519.PubKey":
+		if len(key) != ed25519.PubKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key length %d", len(key))
+		}
+		return ed25519.PubKey(key).Address(), nil
+	case "/cosmos.crypto.secp256k1.PubKey":
+		if len(key) != secp256k1.PubKeySize {
+			return nil, fmt.Errorf("invalid secp256k1 public key length %d", len(key))
+		}
+		return (&secp256k1.PubKey{Key: key}).Address(), nil
+	default:
+		return nil, fmt.Errorf("not supported consensus public key type %s", typeUrl)
+	}
+}
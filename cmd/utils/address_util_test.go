@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConsensusAddressFromPubKey(t *testing.T) {
+	ed25519Key := make([]byte, 32)
+	for i := range ed25519Key {
+		ed25519Key[i] = byte(i)
+	}
+
+	address, err := GetConsensusAddressFromPubKey("/cosmos.crypto.ed25519.PubKey", ed25519Key)
+	require.NoError(t, err)
+	hash := sha256.Sum256(ed25519Key)
+	require.Equal(t, hash[:20], address, "ed25519 consensus address is the truncated SHA256 of the key")
+
+	_, err = GetConsensusAddressFromPubKey("/cosmos.crypto.ed25519.PubKey", ed25519Key[:31])
+	require.Error(t, err)
+
+	secp256k1Key := append([]byte{0x02}, ed25519Key...)
+	address, err = GetConsensusAddressFromPubKey("/cosmos.crypto.secp256k1.PubKey", secp256k1Key)
+	require.NoError(t, err)
+	require.Len(t, address, 20)
+
+	_, err = GetConsensusAddressFromPubKey("/cosmos.crypto.sr25519.PubKey", ed25519Key)
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"math/big"
+	"regexp"
+)
+
+var patternEthermintChainId = regexp.MustCompile(`^[a-z\d]+(?:[_-][a-z\d]+)*_(\d+)-\d+$`)
+
+// ParseEvmChainIdFromCosmosChainId returns the EVM chain ID from the Cosmos chain-id of Ethermint format,
+// eg: evmos_9000-1 => 9000. Returns false if the chain-id is not in Ethermint format.
+func ParseEvmChainIdFromCosmosChainId(chainId string) (*big.Int, bool) {
+	matches := patternEthermintChainId.FindStringSubmatch(chainId)
+	if len(matches) != 2 {
+		return nil, false
+	}
+
+	evmChainId, ok := new(big.Int).SetString(matches[1], 10)
+	if !ok || evmChainId.Sign() < 1 {
+		return nil, false
+	}
+
+	return evmChainId, true
+}
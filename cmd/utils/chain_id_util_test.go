@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvmChainIdFromCosmosChainId(t *testing.T) {
+	tests := []struct {
+		chainId    string
+		wantOk     bool
+		evmChainId int64
+	}{
+		{chainId: "evmos_9000-1", wantOk: true, evmChainId: 9000},
+		{chainId: "evmos_9001-2", wantOk: true, evmChainId: 9001},
+		{chainId: "dymension_1100-1", wantOk: true, evmChainId: 1100},
+		{chainId: "my_rollapp_123-1", wantOk: true, evmChainId: 123},
+		{chainId: "ethermint_0-1", wantOk: false},
+		{chainId: "cosmoshub-4", wantOk: false},
+		{chainId: "evmos_9000", wantOk: false},
+		{chainId: "Evmos_9000-1", wantOk: false},
+		{chainId: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chainId, func(t *testing.T) {
+			evmChainId, ok := ParseEvmChainIdFromCosmosChainId(tt.chainId)
+			require.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				require.Equal(t, tt.evmChainId, evmChainId.Int64())
+			} else {
+				require.Nil(t, evmChainId)
+			}
+		})
+	}
+}
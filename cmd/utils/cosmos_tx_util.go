@@ -0,0 +1,474 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	TypeUrlMsgEthereumTx = "/ethermint.evm.v1.MsgEthereumTx"
+
+	typeUrlEthermintLegacyTx      = "/ethermint.evm.v1.LegacyTx"
+	typeUrlEthermintAccessListTx  = "/ethermint.evm.v1.AccessListTx"
+	typeUrlEthermintDynamicFeeTx  = "/ethermint.evm.v1.DynamicFeeTx"
+	typeUrlEthermintEthSecp256k1  = "/ethermint.crypto.v1.ethsecp256k1.PubKey"
+	typeUrlCosmosEvmEthSecp256k1  = "/cosmos.evm.crypto.v1.ethsecp256k1.PubKey"
+	typeUrlCosmosEvmMsgEthereumTx = "/cosmos.evm.vm.v1.MsgEthereumTx"
+)
+
+var cosmosTxCodec = newCosmosTxCodec()
+
+func newCosmosTxCodec() *codec.ProtoCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	authz.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	distrtypes.RegisterInterfaces(registry)
+	feegrant.RegisterInterfaces(registry)
+	govv1.RegisterInterfaces(registry)
+	govv1beta1.RegisterInterfaces(registry)
+	slashingtypes.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	upgradetypes.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// CosmosTx is a decoded protobuf-encoded Cosmos tx.
+type CosmosTx struct {
+	Raw      *txtypes.TxRaw
+	Body     *txtypes.TxBody
+	AuthInfo *txtypes.AuthInfo
+	hash     string
+}
+
+// DecodeRawCosmosTxInput decodes the raw Cosmos tx, provided as hex (with or without 0x prefix) or base64.
+func DecodeRawCosmosTxInput(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	if hexInput := strings.TrimPrefix(input, "0x"); len(hexInput)%2 == 0 && regexp.MustCompile(`^[a-fA-F\d]+$`).MatchString(hexInput) {
+		return hex.DecodeString(hexInput)
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("raw Cosmos tx must be hex or base64 encoded")
+	}
+	return bz, nil
+}
+
+// DecodeCosmosTx decodes the protobuf-encoded TxRaw, including the TxBody and AuthInfo.
+// Messages are kept as Any so txs contain messages of unknown types can still be decoded.
+func DecodeCosmosTx(bz []byte) (*CosmosTx, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(bz); err != nil {
+		return nil, errors.Wrap(err, "failed to decode TxRaw")
+	}
+
+	var body txtypes.TxBody
+	if err := body.Unmarshal(raw.BodyBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to decode TxBody")
+	}
+
+	var authInfo txtypes.AuthInfo
+	if err := authInfo.Unmarshal(raw.AuthInfoBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to decode AuthInfo")
+	}
+
+	hash := sha256.Sum256(bz)
+
+	return &CosmosTx{
+		Raw:      &raw,
+		Body:     &body,
+		AuthInfo: &authInfo,
+		hash:     strings.ToUpper(hex.EncodeToString(hash[:])),
+	}, nil
+}
+
+// Hash returns the Cosmos tx hash, upper-case hex of SHA256 of the raw tx.
+func (tx *CosmosTx) Hash() string {
+	return tx.hash
+}
+
+// MessageTypes returns type URL of the messages.
+func (tx *CosmosTx) MessageTypes() []string {
+	types := make([]string, len(tx.Body.Messages))
+	for i, msg := range tx.Body.Messages {
+		types[i] = msg.TypeUrl
+	}
+	return types
+}
+
+// HasMsgEthereumTx returns true if the tx contains any MsgEthereumTx.
+func (tx *CosmosTx) HasMsgEthereumTx() bool {
+	for _, msg := range tx.Body.Messages {
+		if isMsgEthereumTx(msg.TypeUrl) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalPrettyJson returns the JSON of the tx, messages are decoded using the known types,
+// MsgEthereumTx is unwrapped into the inner EVM tx.
+// Messages of unknown type are represented by the type URL and the base64 encoded value.
+func (tx *CosmosTx) MarshalPrettyJson() ([]byte, error) {
+	messages := make([]json.RawMessage, len(tx.Body.Messages))
+	for i, msg := range tx.Body.Messages {
+		bz, err := marshalJsonAny(msg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal message %d", i)
+		}
+		messages[i] = bz
+	}
+
+	var options []*codectypes.Any
+	options = append(options, tx.Body.ExtensionOptions...)
+	options = append(options, tx.Body.NonCriticalExtensionOptions...)
+	extensionOptions := make([]json.RawMessage, 0, len(options))
+	for _, option := range options {
+		bz, err := marshalJsonAny(option)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal extension option")
+		}
+		extensionOptions = append(extensionOptions, bz)
+	}
+
+	var fee json.RawMessage
+	if tx.AuthInfo.Fee != nil {
+		var err error
+		fee, err = cosmosTxCodec.MarshalJSON(tx.AuthInfo.Fee)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal fee")
+		}
+	}
+
+	signerInfos := make([]map[string]any, len(tx.AuthInfo.SignerInfos))
+	for i, signerInfo := range tx.AuthInfo.SignerInfos {
+		info := map[string]any{
+			"sequence": signerInfo.Sequence,
+		}
+		if signerInfo.PublicKey != nil {
+			bz, err := marshalJsonAny(signerInfo.PublicKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal public key of signer")
+			}
+			info["publicKey"] = json.RawMessage(bz)
+		}
+		if signerInfo.ModeInfo != nil {
+			bz, err := cosmosTxCodec.MarshalJSON(signerInfo.ModeInfo)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal mode info of signer")
+			}
+			info["modeInfo"] = json.RawMessage(bz)
+		}
+		signerInfos[i] = info
+	}
+
+	signatures := make([]string, len(tx.Raw.Signatures))
+	for i, signature := range tx.Raw.Signatures {
+		signatures[i] = base64.StdEncoding.EncodeToString(signature)
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"hash":             tx.hash,
+		"messages":         messages,
+		"memo":             tx.Body.Memo,
+		"timeoutHeight":    tx.Body.TimeoutHeight,
+		"extensionOptions": extensionOptions,
+		"fee":              fee,
+		"signerInfos":      signerInfos,
+		"signatures":       signatures,
+	}, "", "  ")
+}
+
+func isMsgEthereumTx(typeUrl string) bool {
+	return typeUrl == TypeUrlMsgEthereumTx || typeUrl == typeUrlCosmosEvmMsgEthereumTx
+}
+
+func marshalJsonAny(protoAny *codectypes.Any) ([]byte, error) {
+	if isMsgEthereumTx(protoAny.TypeUrl) {
+		return marshalJsonMsgEthereumTx(protoAny)
+	}
+
+	if protoAny.TypeUrl == typeUrlEthermintEthSecp256k1 || protoAny.TypeUrl == typeUrlCosmosEvmEthSecp256k1 {
+		// same layout as the Cosmos secp256k1 public key, field 1 is the compressed key
+		fields, err := decodeProtoFields(protoAny.Value)
+		if err == nil {
+			return json.Marshal(map[string]any{
+				"@type": protoAny.TypeUrl,
+				"key":   base64.StdEncoding.EncodeToString(fields.bytes(1)),
+			})
+		}
+	}
+
+	msg, err := cosmosTxCodec.InterfaceRegistry().Resolve(protoAny.TypeUrl)
+	if protoMsg, ok := msg.(codec.ProtoMarshaler); err == nil && ok {
+		if err := cosmosTxCodec.Unmarshal(protoAny.Value, protoMsg); err == nil {
+			bz, err := cosmosTxCodec.MarshalJSON(msg)
+			if err == nil {
+				return injectJsonType(bz, protoAny.TypeUrl)
+			}
+		}
+	}
+
+	return json.Marshal(map[string]any{
+		"@type": protoAny.TypeUrl,
+		"value": base64.StdEncoding.EncodeToString(protoAny.Value),
+	})
+}
+
+func injectJsonType(bz []byte, typeUrl string) ([]byte, error) {
+	var _map map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &_map); err != nil {
+		return nil, err
+	}
+	_map["@type"], _ = json.Marshal(typeUrl)
+	return json.Marshal(_map)
+}
+
+// marshalJsonMsgEthereumTx decodes the MsgEthereumTx of Ethermint-based chains without depending on Ethermint,
+// the inner EVM tx is represented like the output of MarshalPrettyJsonEvmTx.
+func marshalJsonMsgEthereumTx(protoAny *codectypes.Any) ([]byte, error) {
+	fields, err := decodeProtoFields(protoAny.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode MsgEthereumTx")
+	}
+
+	output := map[string]any{
+		"@type": protoAny.TypeUrl,
+	}
+
+	var evmTx *ethtypes.Transaction
+	if raw := fields.bytes(6); len(raw) > 0 {
+		// newer layout: from (5) is bytes, raw (6) is the binary encoded EVM tx
+		evmTx = &ethtypes.Transaction{}
+		if err := evmTx.UnmarshalBinary(raw); err != nil {
+			return nil, errors.Wrap(err, "failed to decode raw EVM tx of MsgEthereumTx")
+		}
+		if from := fields.bytes(5); len(from) > 0 {
+			output["from"] = common.BytesToAddress(from).Hex()
+		}
+	} else {
+		// legacy layout: data (1) is Any of the EVM tx, hash (3) and from (4) are string
+		if hash := fields.bytes(3); len(hash) > 0 {
+			output["hash"] = string(hash)
+		}
+		if from := fields.bytes(4); len(from) > 0 {
+			output["from"] = string(from)
+		}
+
+		var data codectypes.Any
+		if err := data.Unmarshal(fields.bytes(1)); err != nil {
+			return nil, errors.Wrap(err, "failed to decode data of MsgEthereumTx")
+		}
+		evmTx, err = decodeEthermintTxData(&data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bz, err := MarshalPrettyJsonEvmTx(evmTx, &PrettyMarshalJsonEvmTxOption{
+		InjectFrom:                true,
+		InjectTranslateAbleFields: true,
+	})
+	if err != nil {
+		// signature might be missing or invalid, show the tx without sender
+		bz, err = MarshalPrettyJsonEvmTx(evmTx, &PrettyMarshalJsonEvmTxOption{
+			InjectTranslateAbleFields: true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal inner EVM tx")
+		}
+	}
+	output["tx"] = json.RawMessage(bz)
+
+	return json.Marshal(output)
+}
+
+func decodeEthermintTxData(data *codectypes.Any) (*ethtypes.Transaction, error) {
+	fields, err := decodeProtoFields(data.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", data.TypeUrl)
+	}
+
+	switch data.TypeUrl {
+	case typeUrlEthermintLegacyTx:
+		return ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce:    fields.uint64(1),
+			GasPrice: fields.bigInt(2),
+			Gas:      fields.uint64(3),
+			To:       fields.address(4),
+			Value:    fields.bigInt(5),
+			Data:     fields.bytes(6),
+			V:        fields.bigIntBytes(7),
+			R:        fields.bigIntBytes(8),
+			S:        fields.bigIntBytes(9),
+		}), nil
+	case typeUrlEthermintAccessListTx:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    fields.bigInt(1),
+			Nonce:      fields.uint64(2),
+			GasPrice:   fields.bigInt(3),
+			Gas:        fields.uint64(4),
+			To:         fields.address(5),
+			Value:      fields.bigInt(6),
+			Data:       fields.bytes(7),
+			AccessList: fields.accessList(8),
+			V:          fields.bigIntBytes(9),
+			R:          fields.bigIntBytes(10),
+			S:          fields.bigIntBytes(11),
+		}), nil
+	case typeUrlEthermintDynamicFeeTx:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    fields.bigInt(1),
+			Nonce:      fields.uint64(2),
+			GasTipCap:  fields.bigInt(3),
+			GasFeeCap:  fields.bigInt(4),
+			Gas:        fields.uint64(5),
+			To:         fields.address(6),
+			Value:      fields.bigInt(7),
+			Data:       fields.bytes(8),
+			AccessList: fields.accessList(9),
+			V:          fields.bigIntBytes(10),
+			R:          fields.bigIntBytes(11),
+			S:          fields.bigIntBytes(12),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown EVM tx data type %s", data.TypeUrl)
+	}
+}
+
+// protoFields holds the raw values of a protobuf message, by field number.
+// Varint fields are stored as uint64, length-delimited fields as bytes.
+type protoFields map[protowire.Number][]any
+
+func decodeProtoFields(bz []byte) (protoFields, error) {
+	fields := make(protoFields)
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(bz)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			fields[num] = append(fields[num], v)
+			bz = bz[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(bz)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			fields[num] = append(fields[num], v)
+			bz = bz[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, bz)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			bz = bz[n:]
+		}
+	}
+	return fields, nil
+}
+
+func (f protoFields) uint64(num protowire.Number) uint64 {
+	values := f[num]
+	if len(values) < 1 {
+		return 0
+	}
+	v, _ := values[len(values)-1].(uint64)
+	return v
+}
+
+func (f protoFields) bytes(num protowire.Number) []byte {
+	values := f[num]
+	if len(values) < 1 {
+		return nil
+	}
+	v, _ := values[len(values)-1].([]byte)
+	return v
+}
+
+func (f protoFields) bytesList(num protowire.Number) [][]byte {
+	var list [][]byte
+	for _, value := range f[num] {
+		if v, ok := value.([]byte); ok {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// bigInt reads the decimal string field, as sdk.Int is encoded.
+func (f protoFields) bigInt(num protowire.Number) *big.Int {
+	v, ok := new(big.Int).SetString(string(f.bytes(num)), 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return v
+}
+
+func (f protoFields) bigIntBytes(num protowire.Number) *big.Int {
+	return new(big.Int).SetBytes(f.bytes(num))
+}
+
+// address reads the hex address string field, returns nil for contract creation.
+func (f protoFields) address(num protowire.Number) *common.Address {
+	str := string(f.bytes(num))
+	if str == "" {
+		return nil
+	}
+	address := common.HexToAddress(str)
+	return &address
+}
+
+func (f protoFields) accessList(num protowire.Number) ethtypes.AccessList {
+	var accessList ethtypes.AccessList
+	for _, bz := range f.bytesList(num) {
+		tupleFields, err := decodeProtoFields(bz)
+		if err != nil {
+			continue
+		}
+		tuple := ethtypes.AccessTuple{
+			Address: common.HexToAddress(string(tupleFields.bytes(1))),
+		}
+		for _, key := range tupleFields.bytesList(2) {
+			tuple.StorageKeys = append(tuple.StorageKeys, common.HexToHash(string(key)))
+		}
+		accessList = append(accessList, tuple)
+	}
+	return accessList
+}
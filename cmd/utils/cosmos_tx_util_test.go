@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDecodeCosmosTx(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	evmTx, err := ethtypes.SignNewTx(privateKey, ethtypes.NewEIP155Signer(big.NewInt(9000)), &ethtypes.LegacyTx{
+		Nonce:    5,
+		GasPrice: big.NewInt(1_000_000_000),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	// Ethermint LegacyTx
+	v, r, s := evmTx.RawSignatureValues()
+	var legacyTx []byte
+	legacyTx = protowire.AppendTag(legacyTx, 1, protowire.VarintType)
+	legacyTx = protowire.AppendVarint(legacyTx, evmTx.Nonce())
+	legacyTx = protowire.AppendTag(legacyTx, 2, protowire.BytesType)
+	legacyTx = protowire.AppendString(legacyTx, evmTx.GasPrice().String())
+	legacyTx = protowire.AppendTag(legacyTx, 3, protowire.VarintType)
+	legacyTx = protowire.AppendVarint(legacyTx, evmTx.Gas())
+	legacyTx = protowire.AppendTag(legacyTx, 4, protowire.BytesType)
+	legacyTx = protowire.AppendString(legacyTx, to.Hex())
+	legacyTx = protowire.AppendTag(legacyTx, 5, protowire.BytesType)
+	legacyTx = protowire.AppendString(legacyTx, evmTx.Value().String())
+	legacyTx = protowire.AppendTag(legacyTx, 7, protowire.BytesType)
+	legacyTx = protowire.AppendBytes(legacyTx, v.Bytes())
+	legacyTx = protowire.AppendTag(legacyTx, 8, protowire.BytesType)
+	legacyTx = protowire.AppendBytes(legacyTx, r.Bytes())
+	legacyTx = protowire.AppendTag(legacyTx, 9, protowire.BytesType)
+	legacyTx = protowire.AppendBytes(legacyTx, s.Bytes())
+
+	data, err := (&codectypes.Any{TypeUrl: typeUrlEthermintLegacyTx, Value: legacyTx}).Marshal()
+	require.NoError(t, err)
+
+	// Ethermint MsgEthereumTx
+	var msgEthereumTx []byte
+	msgEthereumTx = protowire.AppendTag(msgEthereumTx, 1, protowire.BytesType)
+	msgEthereumTx = protowire.AppendBytes(msgEthereumTx, data)
+	msgEthereumTx = protowire.AppendTag(msgEthereumTx, 3, protowire.BytesType)
+	msgEthereumTx = protowire.AppendString(msgEthereumTx, evmTx.Hash().Hex())
+
+	msgSend, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+	})
+	require.NoError(t, err)
+
+	bodyBytes, err := (&txtypes.TxBody{
+		Messages: []*codectypes.Any{
+			msgSend,
+			{TypeUrl: TypeUrlMsgEthereumTx, Value: msgEthereumTx},
+			{TypeUrl: "/unknown.v1.MsgUnknown", Value: []byte{0x01}},
+		},
+		Memo:          "hello",
+		TimeoutHeight: 100,
+	}).Marshal()
+	require.NoError(t, err)
+
+	authInfoBytes, err := (&txtypes.AuthInfo{
+		Fee: &txtypes.Fee{
+			Amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 5)),
+			GasLimit: 200000,
+		},
+	}).Marshal()
+	require.NoError(t, err)
+
+	rawTx, err := (&txtypes.TxRaw{
+		BodyBytes:     bodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		Signatures:    [][]byte{{0x01, 0x02}},
+	}).Marshal()
+	require.NoError(t, err)
+
+	for _, input := range []string{hex.EncodeToString(rawTx), "0x" + hex.EncodeToString(rawTx), base64.StdEncoding.EncodeToString(rawTx)} {
+		bz, err := DecodeRawCosmosTxInput(input)
+		require.NoError(t, err)
+		require.Equal(t, rawTx, bz)
+	}
+
+	tx, err := DecodeCosmosTx(rawTx)
+	require.NoError(t, err)
+	require.Len(t, tx.Hash(), 64)
+	require.Equal(t, []string{"/cosmos.bank.v1beta1.MsgSend", TypeUrlMsgEthereumTx, "/unknown.v1.MsgUnknown"}, tx.MessageTypes())
+	require.True(t, tx.HasMsgEthereumTx())
+
+	bz, err := tx.MarshalPrettyJson()
+	require.NoError(t, err)
+
+	var decoded struct {
+		Messages []struct {
+			Type   string          `json:"@type"`
+			Amount []sdk.Coin      `json:"amount"`
+			Hash   string          `json:"hash"`
+			Tx     json.RawMessage `json:"tx"`
+			Value  string          `json:"value"`
+		} `json:"messages"`
+		Memo          string `json:"memo"`
+		TimeoutHeight uint64 `json:"timeoutHeight"`
+		Fee           struct {
+			GasLimit string `json:"gas_limit"`
+		} `json:"fee"`
+		Signatures []string `json:"signatures"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, "hello", decoded.Memo)
+	require.Equal(t, uint64(100), decoded.TimeoutHeight)
+	require.Equal(t, "200000", decoded.Fee.GasLimit)
+	require.Equal(t, []string{"AQI="}, decoded.Signatures)
+	require.Len(t, decoded.Messages, 3)
+
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", decoded.Messages[0].Type)
+	require.Equal(t, "100", decoded.Messages[0].Amount[0].Amount.String())
+
+	require.Equal(t, TypeUrlMsgEthereumTx, decoded.Messages[1].Type)
+	require.Equal(t, evmTx.Hash().Hex(), decoded.Messages[1].Hash)
+	var innerTx map[string]any
+	require.NoError(t, json.Unmarshal(decoded.Messages[1].Tx, &innerTx))
+	require.Equal(t, sender.Hex(), innerTx["_from"])
+	require.Equal(t, evmTx.Hash().Hex(), innerTx["hash"], "re-constructed EVM tx must be identical")
+
+	require.Equal(t, "/unknown.v1.MsgUnknown", decoded.Messages[2].Type)
+	require.Equal(t, "AQ==", decoded.Messages[2].Value)
+
+	_, err = DecodeCosmosTx([]byte("not a tx"))
+	require.Error(t, err)
+}
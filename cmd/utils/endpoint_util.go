@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// GetEvmWsEndpointFromEvmRpc derives the EVM websocket endpoint from the EVM Json-RPC endpoint,
+// http(s) is replaced by ws(s) and the default port 8545 is replaced by 8546.
+// Websocket endpoint is returned as is.
+func GetEvmWsEndpointFromEvmRpc(evmRpc string) (string, error) {
+	u, err := url.Parse(evmRpc)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "ws", "wss":
+		return evmRpc, nil
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("not supported scheme of EVM Json-RPC endpoint: %s", evmRpc)
+	}
+
+	if u.Port() == "8545" {
+		u.Host = net.JoinHostPort(u.Hostname(), "8546")
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvmWsEndpointFromEvmRpc(t *testing.T) {
+	tests := []struct {
+		evmRpc  string
+		want    string
+		wantErr bool
+	}{
+		{evmRpc: "http://localhost:8545", want: "ws://localhost:8546"},
+		{evmRpc: "https://evm.example.com", want: "wss://evm.example.com"},
+		{evmRpc: "https://evm.example.com:8545/path", want: "wss://evm.example.com:8546/path"},
+		{evmRpc: "http://127.0.0.1:18545", want: "ws://127.0.0.1:18545"},
+		{evmRpc: "ws://localhost:8546", want: "ws://localhost:8546"},
+		{evmRpc: "wss://evm.example.com", want: "wss://evm.example.com"},
+		{evmRpc: "localhost:8545", wantErr: true},
+		{evmRpc: "tcp://localhost:8545", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.evmRpc, func(t *testing.T) {
+			got, err := GetEvmWsEndpointFromEvmRpc(tt.evmRpc)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// evmLogsQueryLimitExceededErrorCode is the JSON-RPC error code returned by nodes when `eth_getLogs` exceeds the limits.
+const evmLogsQueryLimitExceededErrorCode = -32005
+
+// evmLogsQueryRangeErrorMessages are the known error messages returned by nodes
+// when `eth_getLogs` exceeds the limit of block range or number of results.
+var evmLogsQueryRangeErrorMessages = []string{
+	"query returned more than",   // geth, Infura: query returned more than 10000 results
+	"block range",                // Ethermint, BSC: exceed maximum block range: 10000, others: block range is too wide
+	"log response size exceeded", // Alchemy
+}
+
+// IsEvmLogsQueryRangeError returns true if the error returned by `eth_getLogs` indicates
+// the block range or the result set is too large.
+func IsEvmLogsQueryRangeError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == evmLogsQueryLimitExceededErrorCode {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, fmt.Sprintf("error code: %d,", evmLogsQueryLimitExceededErrorCode)) {
+		return true
+	}
+	for _, knownMessage := range evmLogsQueryRangeErrorMessages {
+		if strings.Contains(msg, knownMessage) {
+			return true
+		}
+	}
+	return false
+}
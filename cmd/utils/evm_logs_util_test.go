@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRpcError struct {
+	code int
+	msg  string
+}
+
+func (e testRpcError) Error() string  { return e.msg }
+func (e testRpcError) ErrorCode() int { return e.code }
+
+func TestIsEvmLogsQueryRangeError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "geth max results", err: errors.New("error code: -32000, message: query returned more than 10000 results"), want: true},
+		{name: "ethermint max block range", err: errors.New("error code: -32000, message: exceed maximum block range: 10000"), want: true},
+		{name: "block range too wide", err: errors.New("error code: -32602, message: Block range is too wide"), want: true},
+		{name: "alchemy response size", err: errors.New("error code: -32602, message: Log response size exceeded."), want: true},
+		{name: "code -32005 in message", err: errors.New("error code: -32005, message: query timeout"), want: true},
+		{name: "code -32005 wrapped rpc error", err: fmt.Errorf("failed: %w", testRpcError{code: -32005, msg: "too much"}), want: true},
+		{name: "other rpc error code", err: testRpcError{code: -32000, msg: "header not found"}, want: false},
+		{name: "code -320050", err: errors.New("error code: -320050, message: unknown"), want: false},
+		{name: "rate limit", err: errors.New("error code: -32000, message: rate limit reached"), want: false},
+		{name: "timeout", err: errors.New("Post \"http://localhost:8545\": context deadline exceeded (Client.Timeout exceeded while awaiting headers)"), want: false},
+		{name: "non-OK status", err: errors.New("non-OK status code: 502"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsEvmLogsQueryRangeError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bcdevtools/devd/v3/constants"
+	"github.com/pkg/errors"
+)
+
+// GetDevdHomeDir returns the directory where devd stores its config and data, default is ~/.devd.
+// It can be overridden by setting environment variable DEVD_HOME.
+func GetDevdHomeDir() (string, error) {
+	if homeFromEnv := os.Getenv(constants.ENV_HOME); len(homeFromEnv) > 0 {
+		return homeFromEnv, nil
+	}
+
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user home directory")
+	}
+
+	return filepath.Join(userHomeDir, constants.DEFAULT_HOME_DIR_NAME), nil
+}
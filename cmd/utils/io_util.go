@@ -3,6 +3,8 @@ package utils
 import (
 	"fmt"
 	"os"
+
+	"golang.org/x/term"
 )
 
 // PrintlnStdErr does println to StdErr
@@ -14,3 +16,20 @@ func PrintlnStdErr(a ...any) {
 func PrintfStdErr(format string, a ...any) {
 	_, _ = fmt.Fprintf(os.Stderr, format, a...)
 }
+
+// PromptPassword prints the prompt to StdErr then reads the input from terminal without echoing.
+func PromptPassword(prompt string) (string, error) {
+	stdinFd := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdinFd) {
+		return "", fmt.Errorf("stdin is not a terminal, cannot prompt for password")
+	}
+
+	PrintfStdErr("%s: ", prompt)
+	bz, err := term.ReadPassword(stdinFd)
+	PrintlnStdErr()
+	if err != nil {
+		return "", err
+	}
+
+	return string(bz), nil
+}
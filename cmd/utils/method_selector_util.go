@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// wellKnownMethodSignatures are signatures of the commonly used methods, used to decode selectors without ABI.
+var wellKnownMethodSignatures = []string{
+	// ERC-20
+	"name()",
+	"symbol()",
+	"decimals()",
+	"totalSupply()",
+	"balanceOf(address)",
+	"allowance(address,address)",
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	// ERC-2612
+	"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)",
+	"nonces(address)",
+	// WETH
+	"deposit()",
+	"withdraw(uint256)",
+	// ERC-165
+	"supportsInterface(bytes4)",
+	// ERC-721
+	"ownerOf(uint256)",
+	"tokenURI(uint256)",
+	"getApproved(uint256)",
+	"setApprovalForAll(address,bool)",
+	"isApprovedForAll(address,address)",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	// ERC-1155
+	"balanceOfBatch(address[],uint256[])",
+	"safeTransferFrom(address,address,uint256,uint256,bytes)",
+	"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+	// Multicall
+	"multicall(bytes[])",
+	"aggregate((address,bytes)[])",
+	"aggregate3((address,bool,bytes)[])",
+	// Uniswap V2
+	"getReserves()",
+	"swap(uint256,uint256,address,bytes)",
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+	"swapExactETHForTokens(uint256,address[],address,uint256)",
+	"swapExactTokensForETH(uint256,uint256,address[],address,uint256)",
+	"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+	// Proxy
+	"implementation()",
+	"upgradeTo(address)",
+	"upgradeToAndCall(address,bytes)",
+}
+
+var wellKnownMethodSelectors = func() map[[4]byte]string {
+	selectors := make(map[[4]byte]string, len(wellKnownMethodSignatures))
+	for _, signature := range wellKnownMethodSignatures {
+		var selector [4]byte
+		copy(selector[:], crypto.Keccak256([]byte(signature))[:4])
+		selectors[selector] = signature
+	}
+	return selectors
+}()
+
+// LookupWellKnownMethodSignature returns the signature of the commonly used method which has the given 4 bytes selector.
+func LookupWellKnownMethodSignature(selector []byte) (signature string, found bool) {
+	if len(selector) != 4 {
+		return "", false
+	}
+	signature, found = wellKnownMethodSelectors[[4]byte(selector)]
+	return
+}
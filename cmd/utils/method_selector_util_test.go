@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupWellKnownMethodSignature(t *testing.T) {
+	tests := []struct {
+		selector  string
+		signature string
+		found     bool
+	}{
+		{selector: "0xa9059cbb", signature: "transfer(address,uint256)", found: true},
+		{selector: "0x23b872dd", signature: "transferFrom(address,address,uint256)", found: true},
+		{selector: "0x095ea7b3", signature: "approve(address,uint256)", found: true},
+		{selector: "0x70a08231", signature: "balanceOf(address)", found: true},
+		{selector: "0x42842e0e", signature: "safeTransferFrom(address,address,uint256)", found: true},
+		{selector: "0xdeadbeef", found: false},
+		{selector: "0xa9059c", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			signature, found := LookupWellKnownMethodSignature(hexutil.MustDecode(tt.selector))
+			require.Equal(t, tt.found, found)
+			require.Equal(t, tt.signature, signature)
+		})
+	}
+}
+
+func TestWellKnownMethodSignaturesAreValid(t *testing.T) {
+	for _, signature := range wellKnownMethodSignatures {
+		method, err := ParseMethodSignature(signature)
+		require.NoError(t, err, signature)
+		require.Equal(t, signature, method.Sig)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatTable OutputFormat = "table"
+	OutputFormatJson  OutputFormat = "json"
+	OutputFormatYaml  OutputFormat = "yaml"
+)
+
+// ParseOutputFormat parses the output format, empty input is treated as table.
+func ParseOutputFormat(input string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(input))) {
+	case "", OutputFormatTable:
+		return OutputFormatTable, nil
+	case OutputFormatJson:
+		return OutputFormatJson, nil
+	case OutputFormatYaml, "yml":
+		return OutputFormatYaml, nil
+	default:
+		return "", fmt.Errorf("unsupported output format [%s], supported: %s, %s, %s", input, OutputFormatTable, OutputFormatJson, OutputFormatYaml)
+	}
+}
+
+// RenderOutput renders the data in the given format.
+// For table format, printTable will be called to print the human-readable output,
+// if printTable is nil, the data will be rendered as beautified JSON.
+// Data can be any JSON-marshal-able value, including json.RawMessage.
+func RenderOutput(format OutputFormat, data any, printTable func()) (string, error) {
+	if format == OutputFormatTable && printTable != nil {
+		printTable()
+		return "", nil
+	}
+
+	bz, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	if format == OutputFormatYaml {
+		bz, err = yaml.JSONToYAML(bz)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(bz), "\n"), nil
+	}
+
+	bz, err = BeautifyJson(bz)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bz), nil
+}
+
+// PrintOutput renders the data in the given format and prints it, exit on error.
+// See RenderOutput for more details.
+func PrintOutput(format OutputFormat, data any, printTable func()) {
+	output, err := RenderOutput(format, data, printTable)
+	ExitOnErr(err, "failed to render output")
+
+	if format == OutputFormatTable && printTable != nil {
+		return
+	}
+
+	fmt.Println(output)
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{input: "", want: OutputFormatTable},
+		{input: "table", want: OutputFormatTable},
+		{input: "json", want: OutputFormatJson},
+		{input: " JSON ", want: OutputFormatJson},
+		{input: "yaml", want: OutputFormatYaml},
+		{input: "yml", want: OutputFormatYaml},
+		{input: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseOutputFormat(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderOutput(t *testing.T) {
+	type row struct {
+		Symbol  string `json:"symbol"`
+		Balance string `json:"balance"`
+	}
+	data := []row{{Symbol: "ETH", Balance: "1.0"}}
+
+	t.Run("json", func(t *testing.T) {
+		output, err := RenderOutput(OutputFormatJson, data, func() {
+			t.Fatal("table printer must not be called")
+		})
+		require.NoError(t, err)
+		require.Equal(t, `[
+  {
+    "symbol": "ETH",
+    "balance": "1.0"
+  }
+]`, output)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		output, err := RenderOutput(OutputFormatYaml, data, nil)
+		require.NoError(t, err)
+		require.Equal(t, `- balance: "1.0"
+  symbol: ETH`, output)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var called bool
+		output, err := RenderOutput(OutputFormatTable, data, func() {
+			called = true
+		})
+		require.NoError(t, err)
+		require.True(t, called)
+		require.Empty(t, output)
+	})
+
+	t.Run("table without printer fallback to json", func(t *testing.T) {
+		output, err := RenderOutput(OutputFormatTable, json.RawMessage(`{"a":1}`), nil)
+		require.NoError(t, err)
+		require.Equal(t, `{
+  "a": 1
+}`, output)
+	})
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	httpclient "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// IsEndpointUnreachable returns true if the error indicates the endpoint can not be reached,
+// eg: connection refused, connection reset, DNS lookup failure or dial timeout.
+// Errors returned by a responding endpoint, eg: method not found, are not considered unreachable.
+func IsEndpointUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// CheckEvmRpcReady returns the latest block number if the EVM Json-RPC is responding.
+func CheckEvmRpcReady(ctx context.Context, evmRpc string) (height int64, err error) {
+	ethClient, err := ethclient.DialContext(ctx, evmRpc)
+	if err != nil {
+		return 0, err
+	}
+	defer ethClient.Close()
+
+	blockNumber, err := ethClient.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(blockNumber), nil
+}
+
+// CheckTmRpcReady returns the latest block height if the Tendermint RPC is responding.
+func CheckTmRpcReady(ctx context.Context, tmRpc string) (height int64, err error) {
+	tendermintRpcHttpClient, err := httpclient.New(tmRpc, "/websocket")
+	if err != nil {
+		return 0, err
+	}
+
+	resStatus, err := tendermintRpcHttpClient.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return resStatus.SyncInfo.LatestBlockHeight, nil
+}
+
+// CheckCosmosRestReady returns the latest block height if the Rest API is responding.
+// If the Rest API does not serve the latest block, height is -1.
+func CheckCosmosRestReady(ctx context.Context, rest string) (height int64, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rest+"/cosmos/base/tendermint/v1beta1/blocks/latest", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotImplemented || resp.StatusCode == http.StatusNotFound {
+		return -1, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch latest block! Status code: %d", resp.StatusCode)
+	}
+
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var response struct {
+		Block struct {
+			Header struct {
+				Height string `json:"height"`
+			} `json:"header"`
+		} `json:"block"`
+	}
+	if err := json.Unmarshal(bz, &response); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(response.Block.Header.Height, 10, 64)
+}
+
+// WaitUntil calls the check function at each interval until it returns true,
+// returns false if timeout reached before that.
+func WaitUntil(timeout, interval time.Duration, check func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if check() {
+			return true
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return false
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsEndpointUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedEndpoint := "http://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	_, err = http.Get(closedEndpoint)
+	require.Error(t, err)
+	require.True(t, IsEndpointUnreachable(err), "connection refused")
+	require.True(t, IsEndpointUnreachable(errors.Wrap(err, "wrapped")), "wrapped connection refused")
+	require.True(t, IsEndpointUnreachable(fmt.Errorf("post failed: %w", err)), "wrapped connection refused")
+
+	_, err = CheckEvmRpcReady(context.Background(), closedEndpoint)
+	require.True(t, IsEndpointUnreachable(err), "EVM Json-RPC connection refused")
+
+	_, err = CheckTmRpcReady(context.Background(), closedEndpoint)
+	require.True(t, IsEndpointUnreachable(err), "Tendermint RPC connection refused")
+
+	_, err = CheckCosmosRestReady(context.Background(), closedEndpoint)
+	require.True(t, IsEndpointUnreachable(err), "Rest API connection refused")
+
+	require.False(t, IsEndpointUnreachable(nil))
+	require.False(t, IsEndpointUnreachable(errors.New("the method eth_blockNumber does not exist/is not available")))
+}
+
+func TestCheckCosmosRestReady(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok/cosmos/base/tendermint/v1beta1/blocks/latest":
+			_, _ = w.Write([]byte(`{"block":{"header":{"height":"123"}}}`))
+		case "/error/cosmos/base/tendermint/v1beta1/blocks/latest":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer server.Close()
+
+	height, err := CheckCosmosRestReady(context.Background(), server.URL+"/ok")
+	require.NoError(t, err)
+	require.Equal(t, int64(123), height)
+
+	height, err = CheckCosmosRestReady(context.Background(), server.URL+"/not-supported")
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), height, "responding but latest block is not served")
+
+	_, err = CheckCosmosRestReady(context.Background(), server.URL+"/error")
+	require.Error(t, err)
+	require.False(t, IsEndpointUnreachable(err))
+}
+
+func TestWaitUntil(t *testing.T) {
+	var calls int
+	require.True(t, WaitUntil(time.Second, 10*time.Millisecond, func() bool {
+		calls++
+		return calls == 3
+	}))
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	require.False(t, WaitUntil(50*time.Millisecond, 20*time.Millisecond, func() bool {
+		calls++
+		return false
+	}))
+	require.GreaterOrEqual(t, calls, 2)
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+)
+
+// HashPersonalMessage returns the EIP-191 hash of the message, as used by personal_sign.
+func HashPersonalMessage(message []byte) []byte {
+	return accounts.TextHash(message)
+}
+
+// ParseTypedData parses the EIP-712 typed data JSON, as used by eth_signTypedData_v4.
+// Numeric domain chain ID is accepted, as produced by most wallets & dApps.
+func ParseTypedData(bz []byte) (apitypes.TypedData, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return apitypes.TypedData{}, errors.Wrap(err, "failed to parse typed data JSON")
+	}
+
+	if domainBz, found := raw["domain"]; found {
+		var domain map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(domainBz))
+		decoder.UseNumber()
+		if err := decoder.Decode(&domain); err != nil {
+			return apitypes.TypedData{}, errors.Wrap(err, "failed to parse domain of typed data")
+		}
+		if chainId, ok := domain["chainId"].(json.Number); ok {
+			domain["chainId"] = chainId.String()
+		}
+		var err error
+		raw["domain"], err = json.Marshal(domain)
+		if err != nil {
+			return apitypes.TypedData{}, err
+		}
+	}
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(normalized, &typedData); err != nil {
+		return apitypes.TypedData{}, errors.Wrap(err, "failed to parse typed data")
+	}
+
+	if typedData.PrimaryType == "" {
+		return apitypes.TypedData{}, fmt.Errorf("missing primary type of typed data")
+	}
+
+	return typedData, nil
+}
+
+// HashTypedData returns the EIP-712 hash of the typed data.
+func HashTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash typed data")
+	}
+	return hash, nil
+}
+
+// Signature is the components of a secp256k1 signature, V is 27 or 28.
+type Signature struct {
+	R common.Hash
+	S common.Hash
+	V byte
+}
+
+// NewSignatureFromBytes parses the 65 bytes signature R || S || V, with V is 0/1 or 27/28,
+// or the 64 bytes EIP-2098 compact signature R || (yParity << 255 | S).
+func NewSignatureFromBytes(sig []byte) (Signature, error) {
+	switch len(sig) {
+	case crypto.SignatureLength:
+		v := sig[64]
+		if v < 27 {
+			v += 27
+		}
+		if v != 27 && v != 28 {
+			return Signature{}, fmt.Errorf("invalid recovery id V %d", sig[64])
+		}
+		return Signature{
+			R: common.BytesToHash(sig[:32]),
+			S: common.BytesToHash(sig[32:64]),
+			V: v,
+		}, nil
+	case crypto.SignatureLength - 1:
+		s := common.BytesToHash(sig[32:64])
+		v := byte(27)
+		if s[0]&0x80 != 0 {
+			v = 28
+			s[0] &= 0x7f
+		}
+		return Signature{
+			R: common.BytesToHash(sig[:32]),
+			S: s,
+			V: v,
+		}, nil
+	default:
+		return Signature{}, fmt.Errorf("invalid signature length %d, require 65 bytes or 64 bytes compact signature", len(sig))
+	}
+}
+
+// Bytes returns the 65 bytes signature R || S || V, with V is 27 or 28.
+func (s Signature) Bytes() []byte {
+	return append(append(s.R.Bytes(), s.S.Bytes()...), s.V)
+}
+
+// CompactBytes returns the 64 bytes EIP-2098 compact signature R || (yParity << 255 | S).
+func (s Signature) CompactBytes() []byte {
+	yParityAndS := s.S
+	if s.V == 28 {
+		yParityAndS[0] |= 0x80
+	}
+	return append(s.R.Bytes(), yParityAndS.Bytes()...)
+}
+
+// RecoverSigner recovers the address of the signer of the hash.
+func RecoverSigner(hash []byte, sig Signature) (common.Address, error) {
+	if !crypto.ValidateSignatureValues(sig.V-27, new(big.Int).SetBytes(sig.R.Bytes()), new(big.Int).SetBytes(sig.S.Bytes()), true) {
+		return common.Address{}, fmt.Errorf("invalid signature values")
+	}
+
+	sigBytes := sig.Bytes()
+	sigBytes[64] -= 27
+
+	pubKey, err := crypto.SigToPub(hash, sigBytes)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to recover signer")
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
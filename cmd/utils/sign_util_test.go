@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testTypedDataMail = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestHashPersonalMessageAndRecoverSigner(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	wantSigner := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	require.Equal(t, wantSigner, crypto.PubkeyToAddress(privateKey.PublicKey))
+
+	hash := HashPersonalMessage([]byte("Some data"))
+	require.Equal(t, "0x1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655", hexutil.Encode(hash))
+
+	sigBytes, err := crypto.Sign(hash, privateKey)
+	require.NoError(t, err)
+
+	sig, err := NewSignatureFromBytes(sigBytes)
+	require.NoError(t, err)
+	require.Equal(t, "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c", hexutil.Encode(sig.Bytes()))
+
+	signer, err := RecoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, wantSigner, signer)
+}
+
+func TestHashTypedData(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(testTypedDataMail))
+	require.NoError(t, err)
+
+	hash, err := HashTypedData(typedData)
+	require.NoError(t, err)
+	require.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+
+	privateKey := crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("cow")))
+	sigBytes, err := crypto.Sign(hash, privateKey)
+	require.NoError(t, err)
+
+	sig, err := NewSignatureFromBytes(sigBytes)
+	require.NoError(t, err)
+	require.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d", sig.R.Hex())
+	require.Equal(t, "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562", sig.S.Hex())
+	require.Equal(t, byte(28), sig.V)
+
+	signer, err := RecoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), signer)
+
+	_, err = ParseTypedData([]byte(`{"types": {}}`))
+	require.Error(t, err, "missing primary type")
+}
+
+func TestNewSignatureFromBytes(t *testing.T) {
+	sig65 := hexutil.MustDecode("0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c")
+
+	sig, err := NewSignatureFromBytes(sig65)
+	require.NoError(t, err)
+	require.Equal(t, byte(28), sig.V)
+
+	t.Run("V 0/1 is accepted", func(t *testing.T) {
+		withRecoveryId := common.CopyBytes(sig65)
+		withRecoveryId[64] = 1
+
+		sigFromRecoveryId, err := NewSignatureFromBytes(withRecoveryId)
+		require.NoError(t, err)
+		require.Equal(t, sig, sigFromRecoveryId)
+	})
+
+	t.Run("compact signature round trip", func(t *testing.T) {
+		compact := sig.CompactBytes()
+		require.Len(t, compact, 64)
+		require.NotEqual(t, sig.S.Bytes(), compact[32:], "y parity must be encoded into the highest bit of S")
+
+		sigFromCompact, err := NewSignatureFromBytes(compact)
+		require.NoError(t, err)
+		require.Equal(t, sig, sigFromCompact)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalidV := common.CopyBytes(sig65)
+		invalidV[64] = 29
+		_, err := NewSignatureFromBytes(invalidV)
+		require.Error(t, err)
+
+		_, err = NewSignatureFromBytes(sig65[:63])
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// GetSignatureFromInterface normalizes the given Solidity method/event interface,
+// then computes the keccak256 hash and the 4 bytes signature of it.
+func GetSignatureFromInterface(_interface string) (_4BytesSig string, hash common.Hash, finalInterface string, err error) {
+	_interface = NormalizeEvmEventOrMethodInterface(_interface)
+
+	if !regexp.MustCompile(`^\w+\s*\(.*\)$`).MatchString(_interface) {
+		err = fmt.Errorf("invalid EVM method/event interface, require format: `methodName(...)`")
+		return
+	}
+
+	finalInterface, err = prepareInterfaceToHash(_interface)
+	if err != nil {
+		return
+	}
+
+	hash = common.BytesToHash(crypto.Keccak256([]byte(finalInterface)))
+
+	_4BytesSig = fmt.Sprintf("0x%x", hash[:4])
+	return
+}
+
+// NormalizeEvmEventOrMethodInterface trims the Solidity keywords, modifiers and extra spaces
+// from the method/event interface, argument names and 'indexed' keyword are kept.
+func NormalizeEvmEventOrMethodInterface(_interface string) string {
+	// drop part after ')' if any
+	spl := strings.Split(_interface, ")")
+	if len(spl) > 0 && spl[len(spl)-1] != "" {
+		// drop the last part
+		_interface = strings.Join(spl[:len(spl)-1], ")") + ")"
+	}
+
+	_interface = strings.TrimSpace(_interface)
+	_interface = strings.TrimSuffix(_interface, ";")
+	_interface = strings.TrimSpace(_interface)
+	_interface = strings.TrimSuffix(_interface, "{")
+	_interface = strings.TrimSpace(_interface)
+
+	// remove indexed keyword
+	//_interface = regexp.MustCompile(`[\s\t\n]+indexed[\s\t\n]+`).ReplaceAllString(_interface, " ")
+
+	// remove extra spaces
+
+	_interface = removeExtraSpaces(_interface)
+
+	// remove space surrounding '(' & ')' & ','
+
+	_interface = strings.ReplaceAll(_interface, " (", "(")
+	_interface = strings.ReplaceAll(_interface, "( ", "(")
+	_interface = strings.ReplaceAll(_interface, " )", ")")
+	_interface = strings.ReplaceAll(_interface, ") ,", "),")
+	_interface = strings.ReplaceAll(_interface, ") )", "))")
+	_interface = strings.ReplaceAll(_interface, " ,", ",")
+	_interface = strings.ReplaceAll(_interface, ", ", ",")
+
+	// trim either prefix 'function ', 'event '
+	if strings.HasPrefix(_interface, "function ") {
+		_interface = strings.TrimPrefix(_interface, "function ")
+	} else {
+		_interface = strings.TrimPrefix(_interface, "event ")
+	}
+	_interface = strings.TrimSpace(_interface)
+
+	// ...
+
+	return strings.TrimSpace(_interface) // finalize
+}
+
+//func validateEvmEventOrMethodInterface(_interface string) (ok bool, desc string) {
+//	// validate event/method interface
+//}
+
+func prepareInterfaceToHash(_interface string) (res string, err error) {
+	defer func() {
+		// remove all remaining spaces
+		res = strings.ReplaceAll(res, " ", "")
+	}()
+
+	res = _interface
+
+	// remove indexed keyword
+	res = regexp.MustCompile(`[\s\t\n]+indexed[\s\t\n]+`).ReplaceAllString(res, " ")
+
+	// remove any variable name
+	if !strings.HasSuffix(res, ")") {
+		err = fmt.Errorf("interface must ends with ')': %s", res)
+		return
+	}
+	spl1 := strings.SplitN(res[:len(res)-1] /*remove suffix ')'*/, "(", 2)
+	functionName := strings.TrimSpace(spl1[0])
+	argsPart := strings.TrimSpace(spl1[1])
+
+	var argsPartWithoutVariableName []string
+	if len(argsPart) > 0 {
+		var trimmedFragments []string
+		for _, fragment := range strings.Split(argsPart, ",") {
+			trimmedFragments = append(trimmedFragments, strings.TrimSpace(fragment))
+		}
+
+		argsPart = strings.Join(trimmedFragments, ",")
+		argsPart += "," // add suffix ',' to simplify the logic
+		var parenthesisLevel int
+		var squareBracketLevel int
+		var argName string
+		var meetSpace bool
+		for _, c := range argsPart {
+			if c == ',' {
+				if parenthesisLevel == 0 && squareBracketLevel == 0 {
+					argsPartWithoutVariableName = append(argsPartWithoutVariableName, argName)
+					argName = ""
+					meetSpace = false
+					continue
+				}
+			} else if c == '(' {
+				parenthesisLevel++
+			} else if c == ')' {
+				parenthesisLevel--
+			} else if c == '[' {
+				squareBracketLevel++
+			} else if c == ']' {
+				squareBracketLevel--
+			} else if c == ' ' {
+				if parenthesisLevel == 0 && squareBracketLevel == 0 {
+					meetSpace = true
+				}
+			}
+
+			if meetSpace {
+				continue
+			}
+			argName += string(c)
+		}
+	} else {
+		argsPartWithoutVariableName = []string{}
+	}
+
+	res = fmt.Sprintf("%s(%s)", functionName, strings.Join(argsPartWithoutVariableName, ","))
+	return
+}
+
+func removeExtraSpaces(str string) string {
+	var passOne bool
+	for {
+		var replacedAny bool
+		if strings.Contains(str, "  ") {
+			passOne = false
+			str = strings.ReplaceAll(str, "  ", " ")
+			replacedAny = true
+		}
+		if strings.Contains(str, "\n") {
+			passOne = false
+			str = strings.ReplaceAll(str, "\n", " ")
+			replacedAny = true
+		}
+		if strings.Contains(str, "\t") {
+			passOne = false
+			str = strings.ReplaceAll(str, "\t", " ")
+			replacedAny = true
+		}
+		if replacedAny {
+			continue
+		}
+
+		if !passOne {
+			passOne = true
+			continue // retry one more time
+		}
+
+		break
+	}
+
+	return str
+}
@@ -1,4 +1,4 @@
-package convert
+package utils
 
 import (
 	"strings"
@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func Test_normalizeEvmEventOrMethodInterface(t *testing.T) {
+func Test_NormalizeEvmEventOrMethodInterface(t *testing.T) {
 	tests := []struct {
 		name       string
 		_interface string
@@ -86,7 +86,7 @@ func Test_normalizeEvmEventOrMethodInterface(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			require.Equal(t, tt.want, normalizeEvmEventOrMethodInterface(tt._interface))
+			require.Equal(t, tt.want, NormalizeEvmEventOrMethodInterface(tt._interface))
 		})
 	}
 }
@@ -161,7 +161,7 @@ func Test_prepareInterfaceToHash(t *testing.T) {
 	}
 }
 
-func Test_getSignatureFromInterface(t *testing.T) {
+func Test_GetSignatureFromInterface(t *testing.T) {
 	tests := []struct {
 		_interface    string
 		wantSignature string
@@ -210,7 +210,7 @@ func Test_getSignatureFromInterface(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt._interface, func(t *testing.T) {
-			gotSignature, gotHash, _, err := getSignatureFromInterface(tt._interface)
+			gotSignature, gotHash, _, err := GetSignatureFromInterface(tt._interface)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
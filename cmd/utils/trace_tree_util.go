@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// CallTreeOptions controls rendering of the call frames produced by callTracer.
+type CallTreeOptions struct {
+	// MaxDepth is the maximum depth of nested calls to be rendered, the top-level call is at depth 0.
+	// Non-positive means unlimited.
+	MaxDepth int
+
+	// OnlyFailed prunes the calls those neither failed nor contain any failed nested call.
+	OnlyFailed bool
+
+	// ResolveMethod returns the method signature of the 4 bytes selector, optional.
+	ResolveMethod func(selector []byte) (signature string, found bool)
+}
+
+// RenderCallTree renders the call frames produced by callTracer as an indented tree, one call per line.
+func RenderCallTree(frame map[string]interface{}, opts CallTreeOptions) string {
+	var sb strings.Builder
+	renderCallTreeFrame(&sb, frame, opts, 0, "", "")
+	return sb.String()
+}
+
+func renderCallTreeFrame(sb *strings.Builder, frame map[string]interface{}, opts CallTreeOptions, depth int, linePrefix, childPrefix string) {
+	sb.WriteString(linePrefix)
+	sb.WriteString(describeCallFrame(frame, opts))
+
+	calls := getNestedCallFrames(frame)
+	if opts.OnlyFailed {
+		var failedCalls []map[string]interface{}
+		for _, call := range calls {
+			if HasFailedCallFrame(call) {
+				failedCalls = append(failedCalls, call)
+			}
+		}
+		calls = failedCalls
+	}
+
+	if opts.MaxDepth > 0 && depth >= opts.MaxDepth && len(calls) > 0 {
+		var count int
+		for _, call := range calls {
+			count += countCallFrames(call)
+		}
+		sb.WriteString(fmt.Sprintf(" (+%d nested calls)", count))
+		calls = nil
+	}
+
+	sb.WriteString("\n")
+
+	for i, call := range calls {
+		if i == len(calls)-1 {
+			renderCallTreeFrame(sb, call, opts, depth+1, childPrefix+"└─ ", childPrefix+"   ")
+		} else {
+			renderCallTreeFrame(sb, call, opts, depth+1, childPrefix+"├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+// describeCallFrame returns the call type, from→to, method, value, gas used and the error marker of the call.
+func describeCallFrame(frame map[string]interface{}, opts CallTreeOptions) string {
+	callType := getCallFrameString(frame, "type")
+	parts := []string{
+		fmt.Sprintf("[%s]", callType),
+		fmt.Sprintf("%s→%s", getCallFrameString(frame, "from"), getCallFrameString(frame, "to")),
+	}
+
+	if !strings.HasPrefix(callType, "CREATE") {
+		if input, err := hexutil.Decode(getCallFrameString(frame, "input")); err == nil && len(input) >= 4 {
+			method := hexutil.Encode(input[:4])
+			if opts.ResolveMethod != nil {
+				if signature, found := opts.ResolveMethod(input[:4]); found {
+					method = signature
+				}
+			}
+			parts = append(parts, method)
+		}
+	}
+
+	if value := getTranslatedCallFrameString(frame, "value"); value != "" {
+		parts = append(parts, "value: "+value)
+	}
+	if gasUsed := getTranslatedCallFrameString(frame, "gasUsed"); gasUsed != "" {
+		parts = append(parts, "gasUsed: "+gasUsed)
+	}
+
+	if errStr := getCallFrameString(frame, "error"); errStr != "" {
+		if errStr != vm.ErrExecutionReverted.Error() {
+			parts = append(parts, fmt.Sprintf("[ERROR: %s]", errStr))
+		} else if reason, ok := TryDecodeTraceFrameRevertReason(frame); ok {
+			parts = append(parts, fmt.Sprintf("[REVERTED: %s]", reason))
+		} else {
+			parts = append(parts, "[REVERTED]")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// TryDecodeTraceFrameRevertReason returns the revert reason of the call frame produced by callTracer,
+// returns false if the call was not reverted or the revert reason is not available.
+func TryDecodeTraceFrameRevertReason(frame map[string]interface{}) (reason string, ok bool) {
+	if getCallFrameString(frame, "error") != vm.ErrExecutionReverted.Error() {
+		return "", false
+	}
+
+	if output, err := hexutil.Decode(getCallFrameString(frame, "output")); err == nil {
+		if reason, ok := TryDecodeRevertReason(output); ok {
+			return reason, true
+		}
+	}
+
+	// provided by callTracer since geth v1.11
+	if reason := getCallFrameString(frame, "revertReason"); reason != "" {
+		return reason, true
+	}
+
+	return "", false
+}
+
+// HasFailedCallFrame returns true if the call or any of its nested calls failed.
+func HasFailedCallFrame(frame map[string]interface{}) bool {
+	if getCallFrameString(frame, "error") != "" {
+		return true
+	}
+	for _, call := range getNestedCallFrames(frame) {
+		if HasFailedCallFrame(call) {
+			return true
+		}
+	}
+	return false
+}
+
+func countCallFrames(frame map[string]interface{}) int {
+	count := 1
+	for _, call := range getNestedCallFrames(frame) {
+		count += countCallFrames(call)
+	}
+	return count
+}
+
+func getNestedCallFrames(frame map[string]interface{}) []map[string]interface{} {
+	calls, _ := frame["calls"].([]interface{})
+	frames := make([]map[string]interface{}, 0, len(calls))
+	for _, call := range calls {
+		if callAsMap, ok := call.(map[string]interface{}); ok {
+			frames = append(frames, callAsMap)
+		}
+	}
+	return frames
+}
+
+func getCallFrameString(frame map[string]interface{}, key string) string {
+	str, _ := frame[key].(string)
+	return str
+}
+
+// getTranslatedCallFrameString returns the translated value of the field, injected with '_' prefix, or the original value.
+func getTranslatedCallFrameString(frame map[string]interface{}, key string) string {
+	if translated := getCallFrameString(frame, "_"+key); translated != "" {
+		return translated
+	}
+	return getCallFrameString(frame, key)
+}
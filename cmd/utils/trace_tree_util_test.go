@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCallTree(t *testing.T) {
+	const revertOutput = "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b6e6f7420616c6c6f776564000000000000000000000000000000000000000000"
+
+	var frame map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "CALL", "from": "0xa", "to": "0xb", "value": "0x0", "_value": "0", "gasUsed": "0x100", "_gasUsed": "256",
+		"input": "0x12345678", "output": "`+revertOutput+`", "error": "execution reverted",
+		"calls": [
+			{"type": "STATICCALL", "from": "0xb", "to": "0xc", "gasUsed": "0x10", "input": "0x70a08231"},
+			{"type": "CALL", "from": "0xb", "to": "0xd", "value": "0x1", "gasUsed": "0x20", "input": "0xa9059cbb", "error": "execution reverted", "output": "0x",
+				"calls": [
+					{"type": "DELEGATECALL", "from": "0xd", "to": "0xe", "gasUsed": "0x8", "input": "0xdeadbeef", "error": "out of gas"},
+					{"type": "STATICCALL", "from": "0xd", "to": "0xe", "gasUsed": "0x4", "input": "0x"}
+				]
+			},
+			{"type": "CREATE2", "from": "0xb", "to": "0xf", "value": "0x0", "gasUsed": "0x50", "input": "0x60806040"}
+		]
+	}`), &frame))
+
+	resolveMethod := func(selector []byte) (string, bool) {
+		if signature, found := LookupWellKnownMethodSignature(selector); found {
+			return signature, true
+		}
+		if string(selector) == "\x12\x34\x56\x78" {
+			return "custom()", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name string
+		opts CallTreeOptions
+		want string
+	}{
+		{
+			name: "full tree",
+			opts: CallTreeOptions{ResolveMethod: resolveMethod},
+			want: `[CALL] 0xa→0xb custom() value: 0 gasUsed: 256 [REVERTED: not allowed]
+├─ [STATICCALL] 0xb→0xc balanceOf(address) gasUsed: 0x10
+├─ [CALL] 0xb→0xd transfer(address,uint256) value: 0x1 gasUsed: 0x20 [REVERTED]
+│  ├─ [DELEGATECALL] 0xd→0xe 0xdeadbeef gasUsed: 0x8 [ERROR: out of gas]
+│  └─ [STATICCALL] 0xd→0xe gasUsed: 0x4
+└─ [CREATE2] 0xb→0xf value: 0x0 gasUsed: 0x50
+`,
+		},
+		{
+			name: "without method resolver",
+			opts: CallTreeOptions{MaxDepth: 1},
+			want: `[CALL] 0xa→0xb 0x12345678 value: 0 gasUsed: 256 [REVERTED: not allowed]
+├─ [STATICCALL] 0xb→0xc 0x70a08231 gasUsed: 0x10
+├─ [CALL] 0xb→0xd 0xa9059cbb value: 0x1 gasUsed: 0x20 [REVERTED] (+2 nested calls)
+└─ [CREATE2] 0xb→0xf value: 0x0 gasUsed: 0x50
+`,
+		},
+		{
+			name: "only failed",
+			opts: CallTreeOptions{OnlyFailed: true, ResolveMethod: resolveMethod},
+			want: `[CALL] 0xa→0xb custom() value: 0 gasUsed: 256 [REVERTED: not allowed]
+└─ [CALL] 0xb→0xd transfer(address,uint256) value: 0x1 gasUsed: 0x20 [REVERTED]
+   └─ [DELEGATECALL] 0xd→0xe 0xdeadbeef gasUsed: 0x8 [ERROR: out of gas]
+`,
+		},
+		{
+			name: "only failed with max depth",
+			opts: CallTreeOptions{OnlyFailed: true, MaxDepth: 1, ResolveMethod: resolveMethod},
+			want: `[CALL] 0xa→0xb custom() value: 0 gasUsed: 256 [REVERTED: not allowed]
+└─ [CALL] 0xb→0xd transfer(address,uint256) value: 0x1 gasUsed: 0x20 [REVERTED] (+1 nested calls)
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, RenderCallTree(frame, tt.opts))
+		})
+	}
+}
+
+func TestHasFailedCallFrame(t *testing.T) {
+	require.False(t, HasFailedCallFrame(map[string]interface{}{"type": "CALL"}))
+	require.True(t, HasFailedCallFrame(map[string]interface{}{"type": "CALL", "error": "out of gas"}))
+	require.True(t, HasFailedCallFrame(map[string]interface{}{
+		"type": "CALL",
+		"calls": []interface{}{
+			map[string]interface{}{"type": "CALL"},
+			map[string]interface{}{"type": "CALL", "calls": []interface{}{
+				map[string]interface{}{"type": "CALL", "error": "execution reverted"},
+			}},
+		},
+	}))
+}
+
+func TestTryDecodeTraceFrameRevertReason(t *testing.T) {
+	const revertOutput = "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b6e6f7420616c6c6f776564000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name       string
+		frame      map[string]interface{}
+		wantReason string
+		wantOk     bool
+	}{
+		{
+			name:       "reverted with reason",
+			frame:      map[string]interface{}{"error": "execution reverted", "output": revertOutput},
+			wantReason: "not allowed",
+			wantOk:     true,
+		},
+		{
+			name:       "reverted with reason provided by tracer",
+			frame:      map[string]interface{}{"error": "execution reverted", "output": "0x", "revertReason": "not allowed"},
+			wantReason: "not allowed",
+			wantOk:     true,
+		},
+		{
+			name:   "reverted without reason",
+			frame:  map[string]interface{}{"error": "execution reverted", "output": "0x"},
+			wantOk: false,
+		},
+		{
+			name:   "not reverted",
+			frame:  map[string]interface{}{"error": "out of gas", "output": revertOutput},
+			wantOk: false,
+		},
+		{
+			name:   "succeeded",
+			frame:  map[string]interface{}{"output": "0x"},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := TryDecodeTraceFrameRevertReason(tt.frame)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -18,4 +18,11 @@ const (
 	DEFAULT_TM_RPC      = "http://localhost:26657"
 
 	ENV_SECRET_KEY = "DEVD_SECRET_KEY"
+
+	ENV_HOME = "DEVD_HOME"
+
+	DEFAULT_HOME_DIR_NAME = ".devd"
+	CONFIG_FILE_NAME      = "config.toml"
+	KEYS_DIR_NAME         = "keys"
+	CACHE_DIR_NAME        = "cache"
 )